@@ -0,0 +1,187 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package analytics
+
+import (
+	"reflect"
+	"signify/logging"
+	"time"
+)
+
+// aggregates are the current values of the spaces of a storey or building
+type aggregates struct {
+	PeopleCount    *int
+	OccupiedSpaces *int
+	Temperature    *float64
+	Humidity       *float64
+}
+
+// writtenAggregates holds the aggregates last written for each group asset, guarded by spacesMutex
+var writtenAggregates = make(map[int32]aggregates)
+
+// groupSpaces indexes the spaces by their storey and building asset, so the aggregates of a group only read the
+// spaces of the group. Guarded by spacesMutex.
+var groupSpaces = make(map[int32]map[int32]*spaceState)
+
+// addToGroups adds a space to the index of its storey and building. Must be called with spacesMutex held.
+func addToGroups(state *spaceState) {
+	for _, groupId := range []*int32{state.StoreyAssetId, state.BuildingAssetId} {
+		if groupId == nil {
+			continue
+		}
+		if _, ok := groupSpaces[*groupId]; !ok {
+			groupSpaces[*groupId] = make(map[int32]*spaceState)
+		}
+		groupSpaces[*groupId][state.AssetId] = state
+	}
+}
+
+// removeFromGroups removes a space from the index of its storey and building. Must be called with spacesMutex held.
+func removeFromGroups(state *spaceState) {
+	for _, groupId := range []*int32{state.StoreyAssetId, state.BuildingAssetId} {
+		if groupId == nil {
+			continue
+		}
+		delete(groupSpaces[*groupId], state.AssetId)
+		if len(groupSpaces[*groupId]) == 0 {
+			delete(groupSpaces, *groupId)
+		}
+	}
+}
+
+// groupPeak is the highest total people count of a storey or building on a day
+type groupPeak struct {
+	day   time.Time
+	count int
+}
+
+// groupPeaks holds the peak of the current day for each group asset, guarded by spacesMutex
+var groupPeaks = make(map[int32]*groupPeak)
+
+// updateGroupPeak raises the peak of the group asset to the current total people count of its spaces. The peak
+// starts over with the current total on a new day. Must be called with spacesMutex held.
+func updateGroupPeak(groupId int32, peopleCount *int, now time.Time) {
+	if peopleCount == nil {
+		return
+	}
+	day := startOfDay(now)
+	peak, ok := groupPeaks[groupId]
+	if !ok || peak.day.Before(day) {
+		groupPeaks[groupId] = &groupPeak{day: day, count: *peopleCount}
+		return
+	}
+	if *peopleCount > peak.count {
+		peak.count = *peopleCount
+	}
+}
+
+// currentGroupPeak returns the peak of the group asset for the day, nil if the group has no people count yet
+func currentGroupPeak(groupId int32, now time.Time) *int {
+	peak, ok := groupPeaks[groupId]
+	if !ok || peak.day.Before(startOfDay(now)) {
+		return nil
+	}
+	count := peak.count
+	return &count
+}
+
+// changedAggregates recalculates the aggregates of the storey and building of a space and
+// returns those which differ from the last written ones. Must be called with spacesMutex held.
+func changedAggregates(state *spaceState) map[int32]aggregates {
+	changed := make(map[int32]aggregates)
+	for _, groupId := range []*int32{state.StoreyAssetId, state.BuildingAssetId} {
+		if groupId == nil {
+			continue
+		}
+		current := groupAggregates(*groupId)
+		updateGroupPeak(*groupId, current.PeopleCount, time.Now())
+		if written, ok := writtenAggregates[*groupId]; ok && reflect.DeepEqual(written, current) {
+			continue
+		}
+		writtenAggregates[*groupId] = current
+		changed[*groupId] = current
+	}
+	return changed
+}
+
+// groupAggregates sums the people counts and occupied spaces and averages temperature and humidity of all
+// spaces belonging to the group asset. Values no space of the group has are left empty.
+func groupAggregates(groupId int32) aggregates {
+	var peopleCount, countedSpaces, occupiedSpaces, occupancySpaces, temperatures, humidities int
+	var temperatureSum, humiditySum float64
+	for _, state := range groupSpaces[groupId] {
+		if state.peopleCount != nil {
+			countedSpaces++
+			peopleCount += *state.peopleCount
+		}
+		if state.occupancyTracked {
+			occupancySpaces++
+			if state.occupied {
+				occupiedSpaces++
+			}
+		}
+		if state.temperature != nil {
+			temperatures++
+			temperatureSum += *state.temperature
+		}
+		if state.humidity != nil {
+			humidities++
+			humiditySum += *state.humidity
+		}
+	}
+
+	var result aggregates
+	if countedSpaces > 0 {
+		result.PeopleCount = &peopleCount
+	}
+	if occupancySpaces > 0 {
+		result.OccupiedSpaces = &occupiedSpaces
+	}
+	if temperatures > 0 {
+		temperature := temperatureSum / float64(temperatures)
+		result.Temperature = &temperature
+	}
+	if humidities > 0 {
+		humidity := humiditySum / float64(humidities)
+		result.Humidity = &humidity
+	}
+	return result
+}
+
+// writeAggregates writes the aggregates as input data of the group assets
+func writeAggregates(configId int64, changed map[int32]aggregates) {
+	for assetId, values := range changed {
+		data := make(map[string]any)
+		if values.PeopleCount != nil {
+			data["people_count"] = *values.PeopleCount
+		}
+		if values.OccupiedSpaces != nil {
+			data["occupied_spaces"] = *values.OccupiedSpaces
+		}
+		if values.Temperature != nil {
+			data["temperature"] = *values.Temperature
+		}
+		if values.Humidity != nil {
+			data["humidity"] = *values.Humidity
+		}
+		if len(data) == 0 {
+			continue
+		}
+		if err := upsertInputData(assetId, data); err != nil {
+			logging.Config("analytics", configId).Error("Error writing aggregates of group", "asset_id", assetId, "error", err)
+		}
+	}
+}
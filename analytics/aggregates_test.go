@@ -0,0 +1,133 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package analytics
+
+import (
+	"reflect"
+	"signify/signify"
+	"testing"
+)
+
+// resetState forgets all spaces and derived state kept by the package
+func resetState() {
+	spaces = make(map[int32]*spaceState)
+	groupSpaces = make(map[int32]map[int32]*spaceState)
+	writtenAggregates = make(map[int32]aggregates)
+	groupPeaks = make(map[int32]*groupPeak)
+	sensors = make(map[int32]map[signify.SubscriptionType]*sensorState)
+	statuses = make(map[int32]string)
+}
+
+// spaceValues are the current values of a space, applied to its state
+type spaceValues struct {
+	peopleCount      *int
+	occupancyTracked bool
+	occupied         bool
+	temperature      *float64
+	humidity         *float64
+}
+
+func TestGroupAggregates(t *testing.T) {
+	const storey1, storey2, building = 10, 11, 20
+	topology := []Space{
+		{AssetId: 1, StoreyAssetId: ptr[int32](storey1), BuildingAssetId: ptr[int32](building)},
+		{AssetId: 2, StoreyAssetId: ptr[int32](storey1), BuildingAssetId: ptr[int32](building)},
+		{AssetId: 3, StoreyAssetId: ptr[int32](storey2), BuildingAssetId: ptr[int32](building)},
+	}
+	tests := []struct {
+		name     string
+		topology []Space
+		values   map[int32]spaceValues
+		groupId  int32
+		want     aggregates
+	}{
+		{"unknown group", topology, nil, 99, aggregates{}},
+		{"spaces without values", topology, nil, storey1, aggregates{}},
+		{"sum of the people counts", topology, map[int32]spaceValues{
+			1: {peopleCount: ptr(3)},
+			2: {peopleCount: ptr(4)},
+			3: {peopleCount: ptr(10)},
+		}, storey1, aggregates{PeopleCount: ptr(7)}},
+		{"people count of zero is kept", topology, map[int32]spaceValues{
+			1: {peopleCount: ptr(0)},
+		}, storey1, aggregates{PeopleCount: ptr(0)}},
+		{"occupied spaces of the tracked spaces", topology, map[int32]spaceValues{
+			1: {occupancyTracked: true, occupied: true},
+			2: {occupancyTracked: true},
+			3: {occupied: true},
+		}, building, aggregates{OccupiedSpaces: ptr(1)}},
+		{"no occupied spaces", topology, map[int32]spaceValues{
+			1: {occupancyTracked: true},
+		}, storey1, aggregates{OccupiedSpaces: ptr(0)}},
+		{"average of temperature and humidity", topology, map[int32]spaceValues{
+			1: {temperature: ptr(20.0), humidity: ptr(40.0)},
+			2: {temperature: ptr(22.0)},
+			3: {temperature: ptr(27.0), humidity: ptr(50.0)},
+		}, building, aggregates{Temperature: ptr(23.0), Humidity: ptr(45.0)}},
+		{"spaces of other groups are left out", topology, map[int32]spaceValues{
+			1: {temperature: ptr(20.0)},
+			3: {temperature: ptr(30.0), peopleCount: ptr(2)},
+		}, storey1, aggregates{Temperature: ptr(20.0)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetState()
+			SetSpaces(1, tt.topology)
+			for assetId, values := range tt.values {
+				state := spaces[assetId]
+				state.peopleCount = values.peopleCount
+				state.occupancyTracked = values.occupancyTracked
+				state.occupied = values.occupied
+				state.temperature = values.temperature
+				state.humidity = values.humidity
+			}
+			if got := groupAggregates(tt.groupId); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("groupAggregates() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupAggregatesFollowTopology(t *testing.T) {
+	resetState()
+	SetSpaces(1, []Space{
+		{AssetId: 1, StoreyAssetId: ptr[int32](10)},
+		{AssetId: 2, StoreyAssetId: ptr[int32](10)},
+	})
+	SetSpaces(2, []Space{
+		{AssetId: 3, StoreyAssetId: ptr[int32](30)},
+	})
+	spaces[1].peopleCount = ptr(1)
+	spaces[2].peopleCount = ptr(2)
+	spaces[3].peopleCount = ptr(3)
+
+	// space 2 moves to another storey, space 1 is removed
+	SetSpaces(1, []Space{
+		{AssetId: 2, StoreyAssetId: ptr[int32](11)},
+	})
+	if got := groupAggregates(10); got.PeopleCount != nil {
+		t.Errorf("aggregates of the left storey = %v, want nil", *got.PeopleCount)
+	}
+	if got := groupAggregates(11); !reflect.DeepEqual(got.PeopleCount, ptr(2)) {
+		t.Errorf("aggregates of the new storey = %v, want 2", deref(got.PeopleCount))
+	}
+	if got := groupAggregates(30); !reflect.DeepEqual(got.PeopleCount, ptr(3)) {
+		t.Errorf("aggregates of the other configuration = %v, want 3", deref(got.PeopleCount))
+	}
+	if _, ok := groupSpaces[10]; ok {
+		t.Errorf("index of the left storey is kept")
+	}
+}
@@ -0,0 +1,285 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package analytics
+
+import (
+	"signify/eliona"
+	"signify/logging"
+	"signify/signify"
+	"sync"
+	"time"
+)
+
+// Space locates a space asset in the asset tree, so its values can be aggregated on the group assets
+type Space struct {
+	AssetId         int32
+	StoreyAssetId   *int32
+	BuildingAssetId *int32
+	Capacity        *int
+
+	// SubscriptionType the space reports values for, empty if not known
+	SubscriptionType signify.SubscriptionType
+}
+
+// spaceState is the state of a space kept to derive the utilisation
+type spaceState struct {
+	Space
+	configId int64
+
+	updatedAt time.Time
+	hourStart time.Time
+	dayStart  time.Time
+
+	occupancyTracked bool
+	occupied         bool
+	hourOccupied     time.Duration
+	dayOccupied      time.Duration
+	dayTracked       time.Duration
+
+	peopleCount     *int
+	peakPeopleCount *int
+
+	temperature *float64
+	humidity    *float64
+}
+
+var spaces = make(map[int32]*spaceState)
+var spacesMutex sync.Mutex
+
+// upsertInputData, upsertStatusData and upsertData write to Eliona. Tests replace them to record the writes.
+var (
+	upsertInputData  = eliona.UpsertInputData
+	upsertStatusData = eliona.UpsertStatusData
+	upsertData       = eliona.UpsertData
+)
+
+// SetSpaces replaces the spaces of a configuration. The state of spaces already known is kept.
+func SetSpaces(configId int64, topology []Space) {
+	spacesMutex.Lock()
+	defer spacesMutex.Unlock()
+	known := make(map[int32]bool)
+	now := time.Now()
+	for _, space := range topology {
+		known[space.AssetId] = true
+		if space.SubscriptionType != "" {
+			expectSensor(configId, space.AssetId, space.SubscriptionType, now)
+		}
+		if state, ok := spaces[space.AssetId]; ok {
+			removeFromGroups(state)
+			state.Space = space
+			addToGroups(state)
+			continue
+		}
+		state := &spaceState{
+			Space:     space,
+			configId:  configId,
+			updatedAt: now,
+			hourStart: startOfHour(now),
+			dayStart:  startOfDay(now),
+		}
+		spaces[space.AssetId] = state
+		addToGroups(state)
+	}
+	for assetId, state := range spaces {
+		if state.configId == configId && !known[assetId] {
+			delete(spaces, assetId)
+			removeFromGroups(state)
+			forgetSensors(assetId)
+		}
+	}
+}
+
+// Observe updates the state of a space with a message received for it
+func Observe(assetId int32, message signify.Message) {
+	spacesMutex.Lock()
+	state, ok := spaces[assetId]
+	if !ok {
+		spacesMutex.Unlock()
+		return
+	}
+	state.advance(time.Now())
+	if message.OccupancyState != nil {
+		state.occupancyTracked = true
+		state.occupied = *message.OccupancyState == signify.OccupiedOccupancyState
+	}
+	if message.Count != nil {
+		count := *message.Count
+		state.peopleCount = &count
+		if state.peakPeopleCount == nil || count > *state.peakPeopleCount {
+			state.peakPeopleCount = &count
+		}
+	}
+	if message.Temperature != nil {
+		temperature := *message.Temperature
+		state.temperature = &temperature
+	}
+	if message.Humidity != nil {
+		humidity := *message.Humidity
+		state.humidity = &humidity
+	}
+	changed := changedAggregates(state)
+	spacesMutex.Unlock()
+
+	writeAggregates(state.configId, changed)
+}
+
+// WriteUtilisation writes the derived utilisation of all spaces and the aggregates of their storeys and buildings
+func WriteUtilisation() {
+	spacesMutex.Lock()
+	now := time.Now()
+	spaceData := make(map[int32]map[string]any)
+	spaceConfigIds := make(map[int32]int64)
+	groups := make(map[int32]*groupUtilisation)
+	for assetId, state := range spaces {
+		state.advance(now)
+		spaceData[assetId] = state.utilisation()
+		spaceConfigIds[assetId] = state.configId
+		for _, groupId := range []*int32{state.StoreyAssetId, state.BuildingAssetId} {
+			if groupId == nil {
+				continue
+			}
+			if _, ok := groups[*groupId]; !ok {
+				groups[*groupId] = &groupUtilisation{configId: state.configId}
+			}
+			groups[*groupId].add(state)
+		}
+	}
+	for groupId, group := range groups {
+		updateGroupPeak(groupId, groupAggregates(groupId).PeopleCount, now)
+		group.peakPeopleCount = currentGroupPeak(groupId, now)
+	}
+	spacesMutex.Unlock()
+
+	for assetId, data := range spaceData {
+		if len(data) == 0 {
+			continue
+		}
+		if err := upsertInputData(assetId, data); err != nil {
+			logging.Config("analytics", spaceConfigIds[assetId]).Error("Error writing utilisation of space", "asset_id", assetId, "error", err)
+		}
+	}
+	for assetId, group := range groups {
+		data := group.utilisation()
+		if len(data) == 0 {
+			continue
+		}
+		if err := upsertInputData(assetId, data); err != nil {
+			logging.Config("analytics", group.configId).Error("Error writing utilisation of group", "asset_id", assetId, "error", err)
+		}
+	}
+}
+
+// advance accumulates the occupied time until now, starting new hours and days when passed
+func (s *spaceState) advance(now time.Time) {
+	for s.updatedAt.Before(now) {
+		hourEnd := s.hourStart.Add(time.Hour)
+		dayEnd := s.dayStart.AddDate(0, 0, 1)
+		next := now
+		if hourEnd.Before(next) {
+			next = hourEnd
+		}
+		if dayEnd.Before(next) {
+			next = dayEnd
+		}
+
+		elapsed := next.Sub(s.updatedAt)
+		s.dayTracked += elapsed
+		if s.occupied {
+			s.hourOccupied += elapsed
+			s.dayOccupied += elapsed
+		}
+		s.updatedAt = next
+
+		if !next.Before(hourEnd) {
+			s.hourStart = startOfHour(next)
+			s.hourOccupied = 0
+		}
+		if !next.Before(dayEnd) {
+			s.dayStart = startOfDay(next)
+			s.dayOccupied = 0
+			s.dayTracked = 0
+			s.peakPeopleCount = s.peopleCount
+		}
+	}
+}
+
+// utilisation returns the derived attributes of the space. Only attributes the space has values for are returned.
+func (s *spaceState) utilisation() map[string]any {
+	data := make(map[string]any)
+	if s.occupancyTracked {
+		data["occupied_minutes_hour"] = s.hourOccupied.Minutes()
+		if s.dayTracked > 0 {
+			data["utilisation_day"] = 100 * s.dayOccupied.Seconds() / s.dayTracked.Seconds()
+		}
+	}
+	if s.peakPeopleCount != nil {
+		data["peak_people_count"] = *s.peakPeopleCount
+	}
+	if s.peopleCount != nil && s.Capacity != nil && *s.Capacity > 0 {
+		data["capacity_ratio"] = 100 * float64(*s.peopleCount) / float64(*s.Capacity)
+	}
+	return data
+}
+
+// groupUtilisation aggregates the utilisation of the spaces of a storey or building
+type groupUtilisation struct {
+	configId            int64
+	occupancySpaces     int
+	occupiedMinutesHour float64
+	utilisationDay      float64
+	peakPeopleCount     *int
+	peopleCount         int
+	capacity            int
+}
+
+func (g *groupUtilisation) add(s *spaceState) {
+	if s.occupancyTracked {
+		g.occupancySpaces++
+		g.occupiedMinutesHour += s.hourOccupied.Minutes()
+		if s.dayTracked > 0 {
+			g.utilisationDay += 100 * s.dayOccupied.Seconds() / s.dayTracked.Seconds()
+		}
+	}
+	if s.peopleCount != nil && s.Capacity != nil && *s.Capacity > 0 {
+		g.peopleCount += *s.peopleCount
+		g.capacity += *s.Capacity
+	}
+}
+
+// utilisation returns the averages of the occupancy spaces, the peak of the total people count of the day and the
+// ratio of all people counted to the capacity of their spaces. The peak is left out until people are counted.
+func (g *groupUtilisation) utilisation() map[string]any {
+	data := make(map[string]any)
+	if g.peakPeopleCount != nil {
+		data["peak_people_count"] = *g.peakPeopleCount
+	}
+	if g.occupancySpaces > 0 {
+		data["occupied_minutes_hour"] = g.occupiedMinutesHour / float64(g.occupancySpaces)
+		data["utilisation_day"] = g.utilisationDay / float64(g.occupancySpaces)
+	}
+	if g.capacity > 0 {
+		data["capacity_ratio"] = 100 * float64(g.peopleCount) / float64(g.capacity)
+	}
+	return data
+}
+
+func startOfHour(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
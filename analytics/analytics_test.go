@@ -0,0 +1,214 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package analytics
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func testState(updatedAt time.Time, occupied bool) *spaceState {
+	return &spaceState{
+		updatedAt:        updatedAt,
+		hourStart:        startOfHour(updatedAt),
+		dayStart:         startOfDay(updatedAt),
+		occupancyTracked: true,
+		occupied:         occupied,
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	day := func(d int, hour int, minute int) time.Time {
+		return time.Date(2024, 3, d, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name             string
+		from             time.Time
+		to               time.Time
+		occupied         bool
+		peopleCount      *int
+		peakPeopleCount  *int
+		wantHourOccupied time.Duration
+		wantDayOccupied  time.Duration
+		wantDayTracked   time.Duration
+		wantHourStart    time.Time
+		wantDayStart     time.Time
+		wantPeak         *int
+	}{
+		{
+			name: "within the hour", from: day(1, 10, 0), to: day(1, 10, 30), occupied: true,
+			peopleCount: ptr(3), peakPeopleCount: ptr(8), wantPeak: ptr(8),
+			wantHourOccupied: 30 * time.Minute, wantDayOccupied: 30 * time.Minute, wantDayTracked: 30 * time.Minute,
+			wantHourStart: day(1, 10, 0), wantDayStart: day(1, 0, 0),
+		},
+		{
+			name: "unoccupied is tracked only", from: day(1, 10, 0), to: day(1, 10, 30), occupied: false,
+			wantDayTracked: 30 * time.Minute, wantHourStart: day(1, 10, 0), wantDayStart: day(1, 0, 0),
+		},
+		{
+			name: "crossing an hour", from: day(1, 10, 45), to: day(1, 11, 15), occupied: true,
+			wantHourOccupied: 15 * time.Minute, wantDayOccupied: 30 * time.Minute, wantDayTracked: 30 * time.Minute,
+			wantHourStart: day(1, 11, 0), wantDayStart: day(1, 0, 0),
+		},
+		{
+			name: "ending on an hour", from: day(1, 10, 30), to: day(1, 11, 0), occupied: true,
+			wantDayOccupied: 30 * time.Minute, wantDayTracked: 30 * time.Minute,
+			wantHourStart: day(1, 11, 0), wantDayStart: day(1, 0, 0),
+		},
+		{
+			name: "crossing a day", from: day(1, 23, 30), to: day(2, 0, 20), occupied: true,
+			peopleCount: ptr(3), peakPeopleCount: ptr(8),
+			wantHourOccupied: 20 * time.Minute, wantDayOccupied: 20 * time.Minute, wantDayTracked: 20 * time.Minute,
+			wantHourStart: day(2, 0, 0), wantDayStart: day(2, 0, 0), wantPeak: ptr(3),
+		},
+		{
+			name: "crossing several days", from: day(1, 12, 0), to: day(4, 6, 10), occupied: true,
+			peakPeopleCount:  ptr(8),
+			wantHourOccupied: 10 * time.Minute, wantDayOccupied: 6*time.Hour + 10*time.Minute, wantDayTracked: 6*time.Hour + 10*time.Minute,
+			wantHourStart: day(4, 6, 0), wantDayStart: day(4, 0, 0),
+		},
+		{
+			name: "not before the last update", from: day(1, 10, 30), to: day(1, 10, 0), occupied: true,
+			wantHourStart: day(1, 10, 0), wantDayStart: day(1, 0, 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := testState(tt.from, tt.occupied)
+			state.peopleCount = tt.peopleCount
+			state.peakPeopleCount = tt.peakPeopleCount
+			state.advance(tt.to)
+			if state.hourOccupied != tt.wantHourOccupied {
+				t.Errorf("hourOccupied = %v, want %v", state.hourOccupied, tt.wantHourOccupied)
+			}
+			if state.dayOccupied != tt.wantDayOccupied {
+				t.Errorf("dayOccupied = %v, want %v", state.dayOccupied, tt.wantDayOccupied)
+			}
+			if state.dayTracked != tt.wantDayTracked {
+				t.Errorf("dayTracked = %v, want %v", state.dayTracked, tt.wantDayTracked)
+			}
+			if !state.hourStart.Equal(tt.wantHourStart) {
+				t.Errorf("hourStart = %v, want %v", state.hourStart, tt.wantHourStart)
+			}
+			if !state.dayStart.Equal(tt.wantDayStart) {
+				t.Errorf("dayStart = %v, want %v", state.dayStart, tt.wantDayStart)
+			}
+			if !reflect.DeepEqual(state.peakPeopleCount, tt.wantPeak) {
+				t.Errorf("peakPeopleCount = %v, want %v", deref(state.peakPeopleCount), deref(tt.wantPeak))
+			}
+		})
+	}
+}
+
+func TestSpaceUtilisation(t *testing.T) {
+	tests := []struct {
+		name  string
+		state spaceState
+		want  map[string]any
+	}{
+		{"no values", spaceState{}, map[string]any{}},
+		{"occupancy without tracked time", spaceState{occupancyTracked: true, hourOccupied: 5 * time.Minute},
+			map[string]any{"occupied_minutes_hour": 5.0}},
+		{"occupancy", spaceState{occupancyTracked: true, hourOccupied: 15 * time.Minute, dayOccupied: 30 * time.Minute, dayTracked: time.Hour},
+			map[string]any{"occupied_minutes_hour": 15.0, "utilisation_day": 50.0}},
+		{"occupancy no longer tracked", spaceState{hourOccupied: 15 * time.Minute, dayOccupied: 30 * time.Minute, dayTracked: time.Hour},
+			map[string]any{}},
+		{"people count with capacity", spaceState{Space: Space{Capacity: ptr(20)}, peopleCount: ptr(5), peakPeopleCount: ptr(7)},
+			map[string]any{"peak_people_count": 7, "capacity_ratio": 25.0}},
+		{"people count without capacity", spaceState{Space: Space{Capacity: ptr(0)}, peopleCount: ptr(5), peakPeopleCount: ptr(5)},
+			map[string]any{"peak_people_count": 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.state.utilisation(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("utilisation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupUtilisation(t *testing.T) {
+	tests := []struct {
+		name   string
+		spaces []spaceState
+		peak   *int
+		want   map[string]any
+	}{
+		{"empty group", nil, nil, map[string]any{}},
+		{"averages of the occupancy spaces", []spaceState{
+			{occupancyTracked: true, hourOccupied: 15 * time.Minute, dayOccupied: 30 * time.Minute, dayTracked: time.Hour},
+			{occupancyTracked: true, hourOccupied: 45 * time.Minute, dayOccupied: time.Hour, dayTracked: time.Hour},
+			{hourOccupied: 60 * time.Minute, dayOccupied: time.Hour, dayTracked: time.Hour},
+		}, nil, map[string]any{"occupied_minutes_hour": 30.0, "utilisation_day": 75.0}},
+		{"ratio of all people to the capacity", []spaceState{
+			{Space: Space{Capacity: ptr(20)}, peopleCount: ptr(5)},
+			{Space: Space{Capacity: ptr(20)}, peopleCount: ptr(10)},
+			{Space: Space{Capacity: ptr(50)}},
+			{peopleCount: ptr(30)},
+		}, ptr(15), map[string]any{"capacity_ratio": 37.5, "peak_people_count": 15}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group := groupUtilisation{peakPeopleCount: tt.peak}
+			for i := range tt.spaces {
+				group.add(&tt.spaces[i])
+			}
+			if got := group.utilisation(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("utilisation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupPeak(t *testing.T) {
+	groupPeaks = make(map[int32]*groupPeak)
+	day := func(d int, hour int) time.Time {
+		return time.Date(2024, 3, d, hour, 0, 0, 0, time.UTC)
+	}
+	steps := []struct {
+		name        string
+		now         time.Time
+		peopleCount *int
+		want        *int
+	}{
+		{"no people counted", day(1, 8), nil, nil},
+		{"first count", day(1, 9), ptr(5), ptr(5)},
+		{"lower count keeps the peak", day(1, 10), ptr(3), ptr(5)},
+		{"higher count raises the peak", day(1, 11), ptr(8), ptr(8)},
+		{"no count keeps the peak", day(1, 12), nil, ptr(8)},
+		{"peak of the last day is not reported", day(2, 8), nil, nil},
+		{"new day starts with the current count", day(2, 9), ptr(2), ptr(2)},
+		{"several days later", day(5, 10), ptr(4), ptr(4)},
+	}
+	for _, step := range steps {
+		updateGroupPeak(1, step.peopleCount, step.now)
+		if got := currentGroupPeak(1, step.now); !reflect.DeepEqual(got, step.want) {
+			t.Errorf("%s: currentGroupPeak() = %v, want %v", step.name, deref(got), deref(step.want))
+		}
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func deref[T any](p *T) any {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
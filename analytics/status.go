@@ -0,0 +1,147 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package analytics
+
+import (
+	"signify/logging"
+	"signify/signify"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+const (
+	OnlineStatus = "online"
+	StaleStatus  = "stale"
+)
+
+// sensorState is the time a space last reported values of a subscription type
+type sensorState struct {
+	configId int64
+	lastSeen time.Time
+	stale    bool
+}
+
+// sensors holds the state of each subscription type reported for a space, by the asset id of the space. Sensors and
+// statuses are guarded by spacesMutex.
+var sensors = make(map[int32]map[signify.SubscriptionType]*sensorState)
+var statuses = make(map[int32]string)
+
+// Seen records a message of the subscription type received for a space. A space reporting again is set back online.
+func Seen(configId int64, assetId int32, subscriptionType signify.SubscriptionType) {
+	spacesMutex.Lock()
+	now := time.Now()
+	expectSensor(configId, assetId, subscriptionType, now)
+	sensor := sensors[assetId][subscriptionType]
+	sensor.lastSeen = now
+	sensor.stale = false
+	changed := updateStatus(assetId)
+	spacesMutex.Unlock()
+
+	if changed {
+		writeStatus(configId, assetId, OnlineStatus)
+	}
+}
+
+// CheckStale marks the spaces of a configuration as stale, which didn't report values of a subscription type for
+// longer than the threshold of the type. If occupancyUnknown is set, the occupancy of stale spaces is set to unknown.
+func CheckStale(configId int64, thresholds map[string]time.Duration, occupancyUnknown bool) {
+	spacesMutex.Lock()
+	now := time.Now()
+	var staleAssetIds []int32
+	var unknownOccupancyAssetIds []int32
+	changed := make(map[int32]aggregates)
+	for assetId, spaceSensors := range sensors {
+		becameStale := false
+		for subscriptionType, sensor := range spaceSensors {
+			threshold, ok := thresholds[string(subscriptionType)]
+			if sensor.configId != configId || sensor.stale || !ok || now.Sub(sensor.lastSeen) <= threshold {
+				continue
+			}
+			sensor.stale = true
+			becameStale = true
+			if subscriptionType == signify.OccupancySubscriptionType && occupancyUnknown {
+				unknownOccupancyAssetIds = append(unknownOccupancyAssetIds, assetId)
+				// the space no longer counts for the utilisation and the occupied spaces of its storey and building
+				if state, ok := spaces[assetId]; ok {
+					state.advance(now)
+					state.occupied = false
+					state.occupancyTracked = false
+					for groupId, values := range changedAggregates(state) {
+						changed[groupId] = values
+					}
+				}
+			}
+		}
+		if becameStale && updateStatus(assetId) {
+			staleAssetIds = append(staleAssetIds, assetId)
+		}
+	}
+	spacesMutex.Unlock()
+
+	writeAggregates(configId, changed)
+	logger := logging.Config("analytics", configId)
+	for _, assetId := range staleAssetIds {
+		logger.Warn("Space stopped reporting", "asset_id", assetId)
+		writeStatus(configId, assetId, StaleStatus)
+	}
+	for _, assetId := range unknownOccupancyAssetIds {
+		unknown := signify.UnknownOccupancyState
+		if err := upsertData(assetId, signify.Message{OccupancyState: &unknown, Occupancy: common.Ptr(0)}); err != nil {
+			logger.Error("Error setting occupancy of stale space to unknown", "asset_id", assetId, "error", err)
+		}
+	}
+}
+
+// updateStatus sets the status of a space to stale if any of its subscription types is stale and returns if the
+// status changed. Must be called with spacesMutex held.
+func updateStatus(assetId int32) bool {
+	status := OnlineStatus
+	for _, sensor := range sensors[assetId] {
+		if sensor.stale {
+			status = StaleStatus
+		}
+	}
+	if statuses[assetId] == status {
+		return false
+	}
+	statuses[assetId] = status
+	return true
+}
+
+func writeStatus(configId int64, assetId int32, status string) {
+	if err := upsertStatusData(assetId, map[string]any{"status": status}); err != nil {
+		logging.Config("analytics", configId).Error("Error writing status of space", "asset_id", assetId, "error", err)
+	}
+}
+
+// expectSensor starts tracking the subscription type of a new space as if it reported now, so a space never reporting
+// after the start of the app is marked stale, too. Must be called with spacesMutex held.
+func expectSensor(configId int64, assetId int32, subscriptionType signify.SubscriptionType, now time.Time) {
+	if _, ok := sensors[assetId][subscriptionType]; ok {
+		return
+	}
+	if _, ok := sensors[assetId]; !ok {
+		sensors[assetId] = make(map[signify.SubscriptionType]*sensorState)
+	}
+	sensors[assetId][subscriptionType] = &sensorState{configId: configId, lastSeen: now}
+}
+
+// forgetSensors removes the sensors of a space which is no longer mapped. Must be called with spacesMutex held.
+func forgetSensors(assetId int32) {
+	delete(sensors, assetId)
+	delete(statuses, assetId)
+}
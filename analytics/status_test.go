@@ -0,0 +1,192 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package analytics
+
+import (
+	"reflect"
+	"signify/signify"
+	"testing"
+	"time"
+)
+
+// recordedWrites are the writes to Eliona of a test, by asset id
+type recordedWrites struct {
+	statuses  map[int32][]string
+	data      map[int32][]any
+	inputData map[int32][]map[string]any
+}
+
+// recordWrites replaces the writes to Eliona with recording them for the duration of the test
+func recordWrites(t *testing.T) *recordedWrites {
+	writes := &recordedWrites{
+		statuses:  make(map[int32][]string),
+		data:      make(map[int32][]any),
+		inputData: make(map[int32][]map[string]any),
+	}
+	previousInputData, previousStatusData, previousData := upsertInputData, upsertStatusData, upsertData
+	upsertInputData = func(assetId int32, data map[string]any) error {
+		writes.inputData[assetId] = append(writes.inputData[assetId], data)
+		return nil
+	}
+	upsertStatusData = func(assetId int32, data map[string]any) error {
+		writes.statuses[assetId] = append(writes.statuses[assetId], data["status"].(string))
+		return nil
+	}
+	upsertData = func(assetId int32, data any) error {
+		writes.data[assetId] = append(writes.data[assetId], data)
+		return nil
+	}
+	t.Cleanup(func() {
+		upsertInputData, upsertStatusData, upsertData = previousInputData, previousStatusData, previousData
+	})
+	return writes
+}
+
+// lastSeenBefore sets the last message of a sensor to the duration before now
+func lastSeenBefore(assetId int32, subscriptionType signify.SubscriptionType, d time.Duration) {
+	sensors[assetId][subscriptionType].lastSeen = time.Now().Add(-d)
+}
+
+func TestCheckStale(t *testing.T) {
+	thresholds := map[string]time.Duration{
+		string(signify.OccupancySubscriptionType):   time.Minute,
+		string(signify.TemperatureSubscriptionType): time.Hour,
+	}
+	tests := []struct {
+		name             string
+		occupancy        time.Duration // since the last occupancy message
+		temperature      time.Duration // since the last temperature message
+		occupancyUnknown bool
+		wantStatuses     []string
+		wantUnknown      bool
+	}{
+		{"reporting spaces stay online", 30 * time.Second, 30 * time.Minute, false, nil, false},
+		{"exceeding the threshold of a type", 2 * time.Minute, 30 * time.Minute, false, []string{StaleStatus}, false},
+		{"exceeding the threshold of another type", 30 * time.Second, 2 * time.Hour, false, []string{StaleStatus}, false},
+		{"exceeding all thresholds", 2 * time.Minute, 2 * time.Hour, false, []string{StaleStatus}, false},
+		{"occupancy set to unknown", 2 * time.Minute, 30 * time.Minute, true, []string{StaleStatus}, true},
+		{"occupancy kept for other types", 30 * time.Second, 2 * time.Hour, true, []string{StaleStatus}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetState()
+			writes := recordWrites(t)
+			SetSpaces(1, []Space{{AssetId: 1, SubscriptionType: signify.OccupancySubscriptionType}})
+			expectSensor(1, 1, signify.TemperatureSubscriptionType, time.Now())
+			lastSeenBefore(1, signify.OccupancySubscriptionType, tt.occupancy)
+			lastSeenBefore(1, signify.TemperatureSubscriptionType, tt.temperature)
+
+			CheckStale(1, thresholds, tt.occupancyUnknown)
+			CheckStale(1, thresholds, tt.occupancyUnknown)
+			if !reflect.DeepEqual(writes.statuses[1], tt.wantStatuses) {
+				t.Errorf("statuses = %v, want %v", writes.statuses[1], tt.wantStatuses)
+			}
+			if unknown := len(writes.data[1]) > 0; unknown != tt.wantUnknown {
+				t.Errorf("occupancy set to unknown = %v, want %v", unknown, tt.wantUnknown)
+			}
+		})
+	}
+}
+
+func TestCheckStaleScope(t *testing.T) {
+	resetState()
+	writes := recordWrites(t)
+	SetSpaces(1, []Space{{AssetId: 1, SubscriptionType: signify.OccupancySubscriptionType}})
+	SetSpaces(2, []Space{{AssetId: 2, SubscriptionType: signify.OccupancySubscriptionType}})
+	SetSpaces(1, []Space{{AssetId: 1, SubscriptionType: signify.OccupancySubscriptionType}, {AssetId: 3, SubscriptionType: signify.PeopleCountSubscriptionType}})
+	lastSeenBefore(1, signify.OccupancySubscriptionType, time.Hour)
+	lastSeenBefore(2, signify.OccupancySubscriptionType, time.Hour)
+	lastSeenBefore(3, signify.PeopleCountSubscriptionType, time.Hour)
+
+	CheckStale(1, map[string]time.Duration{string(signify.OccupancySubscriptionType): time.Minute}, false)
+	if !reflect.DeepEqual(writes.statuses[1], []string{StaleStatus}) {
+		t.Errorf("statuses of the space = %v, want stale", writes.statuses[1])
+	}
+	if len(writes.statuses[2]) > 0 {
+		t.Errorf("space of another configuration got statuses %v", writes.statuses[2])
+	}
+	if len(writes.statuses[3]) > 0 {
+		t.Errorf("space without threshold got statuses %v", writes.statuses[3])
+	}
+}
+
+func TestCheckStaleUnknownOccupancy(t *testing.T) {
+	resetState()
+	writes := recordWrites(t)
+	storey := ptr[int32](10)
+	SetSpaces(1, []Space{
+		{AssetId: 1, StoreyAssetId: storey, SubscriptionType: signify.OccupancySubscriptionType},
+		{AssetId: 2, StoreyAssetId: storey, SubscriptionType: signify.OccupancySubscriptionType},
+	})
+	occupied := signify.OccupiedOccupancyState
+	Observe(1, signify.Message{OccupancyState: &occupied})
+	Observe(2, signify.Message{OccupancyState: &occupied})
+	if got := writes.inputData[10]; len(got) == 0 || got[len(got)-1]["occupied_spaces"] != 2 {
+		t.Fatalf("aggregates before = %v, want 2 occupied spaces", got)
+	}
+	lastSeenBefore(1, signify.OccupancySubscriptionType, time.Hour)
+
+	CheckStale(1, map[string]time.Duration{string(signify.OccupancySubscriptionType): time.Minute}, true)
+	unknown := signify.UnknownOccupancyState
+	wantData := []any{signify.Message{OccupancyState: &unknown, Occupancy: ptr(0)}}
+	if !reflect.DeepEqual(writes.data[1], wantData) {
+		t.Errorf("data of the stale space = %v, want unknown occupancy", writes.data[1])
+	}
+	if got := writes.inputData[10]; got[len(got)-1]["occupied_spaces"] != 1 {
+		t.Errorf("aggregates after = %v, want 1 occupied space", got[len(got)-1])
+	}
+	if spaces[1].occupancyTracked {
+		t.Errorf("occupancy of the stale space is still tracked")
+	}
+}
+
+func TestSeen(t *testing.T) {
+	thresholds := map[string]time.Duration{
+		string(signify.OccupancySubscriptionType):   time.Minute,
+		string(signify.TemperatureSubscriptionType): time.Minute,
+	}
+	resetState()
+	writes := recordWrites(t)
+	SetSpaces(1, []Space{{AssetId: 1, SubscriptionType: signify.OccupancySubscriptionType}})
+
+	Seen(1, 1, signify.OccupancySubscriptionType)
+	Seen(1, 1, signify.OccupancySubscriptionType)
+	if !reflect.DeepEqual(writes.statuses[1], []string{OnlineStatus}) {
+		t.Fatalf("statuses after the first messages = %v, want online once", writes.statuses[1])
+	}
+
+	// a space with two stale types is online again once both report
+	Seen(1, 1, signify.TemperatureSubscriptionType)
+	lastSeenBefore(1, signify.OccupancySubscriptionType, time.Hour)
+	lastSeenBefore(1, signify.TemperatureSubscriptionType, time.Hour)
+	CheckStale(1, thresholds, false)
+	Seen(1, 1, signify.OccupancySubscriptionType)
+	want := []string{OnlineStatus, StaleStatus}
+	if !reflect.DeepEqual(writes.statuses[1], want) {
+		t.Fatalf("statuses with one type reporting = %v, want %v", writes.statuses[1], want)
+	}
+	Seen(1, 1, signify.TemperatureSubscriptionType)
+	want = append(want, OnlineStatus)
+	if !reflect.DeepEqual(writes.statuses[1], want) {
+		t.Errorf("statuses after recovery = %v, want %v", writes.statuses[1], want)
+	}
+
+	// a space removed from the topology is forgotten
+	SetSpaces(1, nil)
+	if _, ok := sensors[1]; ok {
+		t.Errorf("sensors of the removed space are kept")
+	}
+}
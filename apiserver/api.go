@@ -12,8 +12,18 @@ package apiserver
 import (
 	"context"
 	"net/http"
+	"time"
 )
 
+// AssetMappingAPIRouter defines the required methods for binding the api requests to a responses for the AssetMappingAPI
+// The AssetMappingAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a AssetMappingAPIServicer to perform the required actions, then write the service results to the http response.
+type AssetMappingAPIRouter interface {
+	DeleteAssetMappingById(http.ResponseWriter, *http.Request)
+	GetAssetMappingById(http.ResponseWriter, *http.Request)
+	GetAssetMappingsByConfigId(http.ResponseWriter, *http.Request)
+}
+
 // ConfigurationAPIRouter defines the required methods for binding the api requests to a responses for the ConfigurationAPI
 // The ConfigurationAPIRouter implementation should parse necessary information from the http request,
 // pass the data to a ConfigurationAPIServicer to perform the required actions, then write the service results to the http response.
@@ -32,6 +42,30 @@ type CustomizationAPIRouter interface {
 	GetDashboardTemplateByName(http.ResponseWriter, *http.Request)
 }
 
+// EventLogAPIRouter defines the required methods for binding the api requests to a responses for the EventLogAPI
+// The EventLogAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a EventLogAPIServicer to perform the required actions, then write the service results to the http response.
+type EventLogAPIRouter interface {
+	GetEvents(http.ResponseWriter, *http.Request)
+}
+
+// HealthAPIRouter defines the required methods for binding the api requests to a responses for the HealthAPI
+// The HealthAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a HealthAPIServicer to perform the required actions, then write the service results to the http response.
+type HealthAPIRouter interface {
+	GetLiveness(http.ResponseWriter, *http.Request)
+	GetReadiness(http.ResponseWriter, *http.Request)
+}
+
+// SynchronizationAPIRouter defines the required methods for binding the api requests to a responses for the SynchronizationAPI
+// The SynchronizationAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a SynchronizationAPIServicer to perform the required actions, then write the service results to the http response.
+type SynchronizationAPIRouter interface {
+	GetSyncJobById(http.ResponseWriter, *http.Request)
+	GetSyncPreviewByConfigId(http.ResponseWriter, *http.Request)
+	PostSyncByConfigId(http.ResponseWriter, *http.Request)
+}
+
 // VersionAPIRouter defines the required methods for binding the api requests to a responses for the VersionAPI
 // The VersionAPIRouter implementation should parse necessary information from the http request,
 // pass the data to a VersionAPIServicer to perform the required actions, then write the service results to the http response.
@@ -40,6 +74,16 @@ type VersionAPIRouter interface {
 	GetVersion(http.ResponseWriter, *http.Request)
 }
 
+// AssetMappingAPIServicer defines the api actions for the AssetMappingAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type AssetMappingAPIServicer interface {
+	DeleteAssetMappingById(context.Context, int64) (ImplResponse, error)
+	GetAssetMappingById(context.Context, int64) (ImplResponse, error)
+	GetAssetMappingsByConfigId(context.Context, int64, string, string, string) (ImplResponse, error)
+}
+
 // ConfigurationAPIServicer defines the api actions for the ConfigurationAPI service
 // This interface intended to stay up to date with the openapi yaml used to generate it,
 // while the service implementation can be ignored with the .openapi-generator-ignore file
@@ -57,7 +101,34 @@ type ConfigurationAPIServicer interface {
 // while the service implementation can be ignored with the .openapi-generator-ignore file
 // and updated with the logic required for the API.
 type CustomizationAPIServicer interface {
-	GetDashboardTemplateByName(context.Context, string, string) (ImplResponse, error)
+	GetDashboardTemplateByName(context.Context, string, string, string, string, int32) (ImplResponse, error)
+}
+
+// EventLogAPIServicer defines the api actions for the EventLogAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type EventLogAPIServicer interface {
+	GetEvents(context.Context, int64, time.Time, int64, int32) (ImplResponse, error)
+}
+
+// HealthAPIServicer defines the api actions for the HealthAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type HealthAPIServicer interface {
+	GetLiveness(context.Context) (ImplResponse, error)
+	GetReadiness(context.Context) (ImplResponse, error)
+}
+
+// SynchronizationAPIServicer defines the api actions for the SynchronizationAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type SynchronizationAPIServicer interface {
+	GetSyncJobById(context.Context, int64) (ImplResponse, error)
+	GetSyncPreviewByConfigId(context.Context, int64) (ImplResponse, error)
+	PostSyncByConfigId(context.Context, int64) (ImplResponse, error)
 }
 
 // VersionAPIServicer defines the api actions for the VersionAPI service
@@ -0,0 +1,150 @@
+/*
+ * Signify app API
+ *
+ * API to access and configure the signify app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// AssetMappingAPIController binds http requests to an api service and writes the service results to the http response
+type AssetMappingAPIController struct {
+	service      AssetMappingAPIServicer
+	errorHandler ErrorHandler
+}
+
+// AssetMappingAPIOption for how the controller is set up.
+type AssetMappingAPIOption func(*AssetMappingAPIController)
+
+// WithAssetMappingAPIErrorHandler inject ErrorHandler into controller
+func WithAssetMappingAPIErrorHandler(h ErrorHandler) AssetMappingAPIOption {
+	return func(c *AssetMappingAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewAssetMappingAPIController creates a default api controller
+func NewAssetMappingAPIController(s AssetMappingAPIServicer, opts ...AssetMappingAPIOption) Router {
+	controller := &AssetMappingAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the AssetMappingAPIController
+func (c *AssetMappingAPIController) Routes() Routes {
+	return Routes{
+		"DeleteAssetMappingById": Route{
+			strings.ToUpper("Delete"),
+			"/v1/assets/{asset-mapping-id}",
+			c.DeleteAssetMappingById,
+		},
+		"GetAssetMappingById": Route{
+			strings.ToUpper("Get"),
+			"/v1/assets/{asset-mapping-id}",
+			c.GetAssetMappingById,
+		},
+		"GetAssetMappingsByConfigId": Route{
+			strings.ToUpper("Get"),
+			"/v1/configs/{config-id}/assets",
+			c.GetAssetMappingsByConfigId,
+		},
+	}
+}
+
+// DeleteAssetMappingById - Deletes an asset mapping
+func (c *AssetMappingAPIController) DeleteAssetMappingById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	assetMappingIdParam, err := parseNumericParameter[int64](
+		params["asset-mapping-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.DeleteAssetMappingById(r.Context(), assetMappingIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetAssetMappingById - Get asset mapping
+func (c *AssetMappingAPIController) GetAssetMappingById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	assetMappingIdParam, err := parseNumericParameter[int64](
+		params["asset-mapping-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.GetAssetMappingById(r.Context(), assetMappingIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetAssetMappingsByConfigId - Get asset mappings of a configuration
+func (c *AssetMappingAPIController) GetAssetMappingsByConfigId(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	query := r.URL.Query()
+	configIdParam, err := parseNumericParameter[int64](
+		params["config-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var kindParam string
+	if query.Has("kind") {
+		param := query.Get("kind")
+
+		kindParam = param
+	}
+	var projectIdParam string
+	if query.Has("projectId") {
+		param := query.Get("projectId")
+
+		projectIdParam = param
+	}
+	var parentUuidParam string
+	if query.Has("parentUuid") {
+		param := query.Get("parentUuid")
+
+		parentUuidParam = param
+	}
+	result, err := c.service.GetAssetMappingsByConfigId(r.Context(), configIdParam, kindParam, projectIdParam, parentUuidParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
@@ -75,7 +75,32 @@ func (c *CustomizationAPIController) GetDashboardTemplateByName(w http.ResponseW
 		c.errorHandler(w, r, &RequiredError{Field: "projectId"}, nil)
 		return
 	}
-	result, err := c.service.GetDashboardTemplateByName(r.Context(), dashboardTemplateNameParam, projectIdParam)
+	var siteUuidParam string
+	if query.Has("siteUuid") {
+		param := query.Get("siteUuid")
+
+		siteUuidParam = param
+	}
+	var buildingUuidParam string
+	if query.Has("buildingUuid") {
+		param := query.Get("buildingUuid")
+
+		buildingUuidParam = param
+	}
+	var assetIdParam int32
+	if query.Has("assetId") {
+		param, err := parseNumericParameter[int32](
+			query.Get("assetId"),
+			WithParse[int32](parseInt32),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		assetIdParam = param
+	}
+	result, err := c.service.GetDashboardTemplateByName(r.Context(), dashboardTemplateNameParam, projectIdParam, siteUuidParam, buildingUuidParam, assetIdParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
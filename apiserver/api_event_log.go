@@ -0,0 +1,124 @@
+/*
+ * Signify app API
+ *
+ * API to access and configure the signify app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// EventLogAPIController binds http requests to an api service and writes the service results to the http response
+type EventLogAPIController struct {
+	service      EventLogAPIServicer
+	errorHandler ErrorHandler
+}
+
+// EventLogAPIOption for how the controller is set up.
+type EventLogAPIOption func(*EventLogAPIController)
+
+// WithEventLogAPIErrorHandler inject ErrorHandler into controller
+func WithEventLogAPIErrorHandler(h ErrorHandler) EventLogAPIOption {
+	return func(c *EventLogAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewEventLogAPIController creates a default api controller
+func NewEventLogAPIController(s EventLogAPIServicer, opts ...EventLogAPIOption) Router {
+	controller := &EventLogAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the EventLogAPIController
+func (c *EventLogAPIController) Routes() Routes {
+	return Routes{
+		"GetEvents": Route{
+			strings.ToUpper("Get"),
+			"/v1/events",
+			c.GetEvents,
+		},
+	}
+}
+
+// GetEvents - Get events
+func (c *EventLogAPIController) GetEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var configIdParam int64
+	if query.Has("configId") {
+		param, err := parseNumericParameter[int64](
+			query.Get("configId"),
+			WithParse[int64](parseInt64),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		configIdParam = param
+	}
+	var sinceParam time.Time
+	if query.Has("since") {
+		param, err := parseTime(query.Get("since"))
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		sinceParam = param
+	}
+	var cursorParam int64
+	if query.Has("cursor") {
+		param, err := parseNumericParameter[int64](
+			query.Get("cursor"),
+			WithParse[int64](parseInt64),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		cursorParam = param
+	}
+	var limitParam int32
+	if query.Has("limit") {
+		param, err := parseNumericParameter[int32](
+			query.Get("limit"),
+			WithParse[int32](parseInt32),
+			WithMinimum[int32](1),
+			WithMaximum[int32](1000),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		limitParam = param
+	} else {
+		var param int32 = 100
+		limitParam = param
+	}
+	result, err := c.service.GetEvents(r.Context(), configIdParam, sinceParam, cursorParam, limitParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
@@ -0,0 +1,131 @@
+/*
+ * Signify app API
+ *
+ * API to access and configure the signify app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// SynchronizationAPIController binds http requests to an api service and writes the service results to the http response
+type SynchronizationAPIController struct {
+	service      SynchronizationAPIServicer
+	errorHandler ErrorHandler
+}
+
+// SynchronizationAPIOption for how the controller is set up.
+type SynchronizationAPIOption func(*SynchronizationAPIController)
+
+// WithSynchronizationAPIErrorHandler inject ErrorHandler into controller
+func WithSynchronizationAPIErrorHandler(h ErrorHandler) SynchronizationAPIOption {
+	return func(c *SynchronizationAPIController) {
+		c.errorHandler = h
+	}
+}
+
+// NewSynchronizationAPIController creates a default api controller
+func NewSynchronizationAPIController(s SynchronizationAPIServicer, opts ...SynchronizationAPIOption) Router {
+	controller := &SynchronizationAPIController{
+		service:      s,
+		errorHandler: DefaultErrorHandler,
+	}
+
+	for _, opt := range opts {
+		opt(controller)
+	}
+
+	return controller
+}
+
+// Routes returns all the api routes for the SynchronizationAPIController
+func (c *SynchronizationAPIController) Routes() Routes {
+	return Routes{
+		"GetSyncJobById": Route{
+			strings.ToUpper("Get"),
+			"/v1/sync-jobs/{sync-job-id}",
+			c.GetSyncJobById,
+		},
+		"GetSyncPreviewByConfigId": Route{
+			strings.ToUpper("Get"),
+			"/v1/configs/{config-id}/preview",
+			c.GetSyncPreviewByConfigId,
+		},
+		"PostSyncByConfigId": Route{
+			strings.ToUpper("Post"),
+			"/v1/configs/{config-id}/sync",
+			c.PostSyncByConfigId,
+		},
+	}
+}
+
+// GetSyncJobById - Get synchronisation job
+func (c *SynchronizationAPIController) GetSyncJobById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	syncJobIdParam, err := parseNumericParameter[int64](
+		params["sync-job-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.GetSyncJobById(r.Context(), syncJobIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetSyncPreviewByConfigId - Preview the assets of a configuration
+func (c *SynchronizationAPIController) GetSyncPreviewByConfigId(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	configIdParam, err := parseNumericParameter[int64](
+		params["config-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.GetSyncPreviewByConfigId(r.Context(), configIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// PostSyncByConfigId - Synchronise a configuration
+func (c *SynchronizationAPIController) PostSyncByConfigId(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	configIdParam, err := parseNumericParameter[int64](
+		params["config-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.PostSyncByConfigId(r.Context(), configIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+}
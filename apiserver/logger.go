@@ -10,23 +10,36 @@
 package apiserver
 
 import (
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
 )
 
+// statusRecorder keeps the status code written to a response
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
 func Logger(inner http.Handler, name string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
 
-		inner.ServeHTTP(w, r)
+		inner.ServeHTTP(recorder, r)
 
-		log.Printf(
-			"%s %s %s %s",
-			r.Method,
-			r.RequestURI,
-			name,
-			time.Since(start),
+		slog.Default().Info("API request",
+			"tag", "api",
+			"method", r.Method,
+			"uri", r.RequestURI,
+			"route", name,
+			"status", recorder.status,
+			"duration", time.Since(start),
 		)
 	})
 }
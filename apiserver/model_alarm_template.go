@@ -0,0 +1,65 @@
+/*
+ * Signify app API
+ *
+ * API to access and configure the signify app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// AlarmTemplate - Alarm rule created for every asset of the asset type. The alarm is triggered if the attribute value is below low, above high or equal to equal.
+type AlarmTemplate struct {
+
+	// Unique name of the template within the configuration
+	Name string `json:"name"`
+
+	// Asset type the alarm rule is created for
+	AssetType string `json:"assetType"`
+
+	// Attribute of the asset type the alarm rule checks
+	Attribute string `json:"attribute"`
+
+	// Subtype of the attribute
+	Subtype string `json:"subtype,omitempty"`
+
+	// Triggers the alarm if the attribute value is less than this value
+	Low *float64 `json:"low,omitempty"`
+
+	// Triggers the alarm if the attribute value is greater than this value
+	High *float64 `json:"high,omitempty"`
+
+	// Triggers the alarm if the attribute value equals this value
+	Equal *float64 `json:"equal,omitempty"`
+
+	// Priority of the alarm (1 high, 2 medium, 3 low, 10 info)
+	Priority int32 `json:"priority,omitempty"`
+
+	// Requires the alarm an acknowledgment
+	RequiresAcknowledge *bool `json:"requiresAcknowledge,omitempty"`
+
+	// Texts of the alarm by language
+	Message map[string]string `json:"message,omitempty"`
+}
+
+// AssertAlarmTemplateRequired checks if the required fields are not zero-ed
+func AssertAlarmTemplateRequired(obj AlarmTemplate) error {
+	elements := map[string]interface{}{
+		"name":      obj.Name,
+		"assetType": obj.AssetType,
+		"attribute": obj.Attribute,
+	}
+	for name, el := range elements {
+		if isZero := IsZeroValue(el); isZero {
+			return &RequiredError{Field: name}
+		}
+	}
+
+	return nil
+}
+
+// AssertAlarmTemplateConstraints checks if the values respects the defined constraints
+func AssertAlarmTemplateConstraints(obj AlarmTemplate) error {
+	return nil
+}
@@ -0,0 +1,51 @@
+/*
+ * Signify app API
+ *
+ * API to access and configure the signify app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// AssetMapping - Mapping between an Interact object and an Eliona asset.
+type AssetMapping struct {
+
+	// Internal identifier of the mapping
+	Id int64 `json:"id,omitempty"`
+
+	// Configuration which created the mapping
+	ConfigId int64 `json:"configId,omitempty"`
+
+	// Eliona project the asset belongs to
+	ProjectId string `json:"projectId,omitempty"`
+
+	// Kind of the mapped object
+	Kind string `json:"kind,omitempty"`
+
+	// Interact UUID of the object
+	Uuid string `json:"uuid,omitempty"`
+
+	// Interact UUID of the parent object
+	ParentUuid *string `json:"parentUuid,omitempty"`
+
+	// Global asset identifier of the Eliona asset
+	GlobalAssetId string `json:"globalAssetId,omitempty"`
+
+	// ID of the Eliona asset
+	AssetId *int32 `json:"assetId,omitempty"`
+
+	// Whether the object is attached to an existing Eliona asset instead of an asset created by the app
+	Attached bool `json:"attached,omitempty"`
+}
+
+// AssertAssetMappingRequired checks if the required fields are not zero-ed
+func AssertAssetMappingRequired(obj AssetMapping) error {
+	return nil
+}
+
+// AssertAssetMappingConstraints checks if the values respects the defined constraints
+func AssertAssetMappingConstraints(obj AssetMapping) error {
+	return nil
+}
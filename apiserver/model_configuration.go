@@ -51,6 +51,27 @@ type Configuration struct {
 	// List of Eliona project ids for which this device should collect data. For each project id all smart devices are automatically created as an asset in Eliona. The mapping between Eliona is stored as an asset mapping in the signify app.
 	ProjectIDs *[]string `json:"projectIDs,omitempty"`
 
+	// List of Interact UUIDs of sites, buildings, storeys or spaces which are never created, regardless of the asset filter. Excluded objects are skipped including their whole subtree, and assets already created for them are deleted with the next synchronisation.
+	ExcludedUUIDs *[]string `json:"excludedUUIDs,omitempty"`
+
+	// List of Eliona projects with an own selection of assets. Projects listed in projectIDs are bound with the asset filter of the configuration only.
+	ProjectBindings *[]ProjectBinding `json:"projectBindings,omitempty"`
+
+	// Seconds without a message after which a space is considered stale, by subscription type (`OCCUPANCY`, `HUMIDITY`, `TEMPERATURE` or `PEOPLE_COUNT`). Spaces of subscription types without threshold are never considered stale.
+	StaleThresholds map[string]int32 `json:"staleThresholds,omitempty"`
+
+	// Set the occupancy of stale spaces to unknown
+	StaleOccupancyUnknown *bool `json:"staleOccupancyUnknown,omitempty"`
+
+	// Alarm rules created for the assets of the app. Alarm rules of removed templates or assets are deleted.
+	AlarmTemplates *[]AlarmTemplate `json:"alarmTemplates,omitempty"`
+
+	// IDs of the Eliona users notified about synchronizations and failures. Defaults to the user who last created or updated the configuration.
+	NotificationRecipients *[]string `json:"notificationRecipients,omitempty"`
+
+	// Interval in seconds in which notifications are collected into one digest per project. Without interval, the users are notified after each synchronization.
+	NotificationDigestInterval *int32 `json:"notificationDigestInterval,omitempty"`
+
 	// ID of the last Eliona user who created or updated the configuration
 	UserId *string `json:"userId,omitempty"`
 }
@@ -60,6 +81,20 @@ func AssertConfigurationRequired(obj Configuration) error {
 	if err := AssertRecurseInterfaceRequired(obj.AssetFilter, AssertFilterRuleRequired); err != nil {
 		return err
 	}
+	if obj.ProjectBindings != nil {
+		for _, el := range *obj.ProjectBindings {
+			if err := AssertProjectBindingRequired(el); err != nil {
+				return err
+			}
+		}
+	}
+	if obj.AlarmTemplates != nil {
+		for _, el := range *obj.AlarmTemplates {
+			if err := AssertAlarmTemplateRequired(el); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
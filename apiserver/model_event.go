@@ -0,0 +1,43 @@
+/*
+ * Signify app API
+ *
+ * API to access and configure the signify app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+import (
+	"time"
+)
+
+// Event - Activity of the app recorded in the event log.
+type Event struct {
+
+	// Internal identifier of the event
+	Id int64 `json:"id,omitempty"`
+
+	// Configuration the event belongs to
+	ConfigId *int64 `json:"configId,omitempty"`
+
+	// Type of the event
+	Type string `json:"type,omitempty"`
+
+	// Description of the event
+	Message string `json:"message,omitempty"`
+
+	// Time the event was recorded
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+}
+
+// AssertEventRequired checks if the required fields are not zero-ed
+func AssertEventRequired(obj Event) error {
+	return nil
+}
+
+// AssertEventConstraints checks if the values respects the defined constraints
+func AssertEventConstraints(obj Event) error {
+	return nil
+}
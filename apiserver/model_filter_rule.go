@@ -11,9 +11,23 @@ package apiserver
 
 // FilterRule - Asset selection rule. Possible parameters are defined in app's README file.
 type FilterRule struct {
+
+	// Level the rule applies to. If not set, the rule applies to all levels.
+	ObjectType *string `json:"objectType,omitempty"`
+
 	Parameter string `json:"parameter,omitempty"`
 
+	// Regular expression the parameter has to match, used by the operator `regex`
 	Regex string `json:"regex,omitempty"`
+
+	// Operator comparing the parameter. Defaults to `regex`.
+	Operator *string `json:"operator,omitempty"`
+
+	// Value the parameter is compared with by the operator
+	Value string `json:"value,omitempty"`
+
+	// Inverts the rule, i.e. the rule matches if the comparison doesn't
+	Negate *bool `json:"negate,omitempty"`
 }
 
 // AssertFilterRuleRequired checks if the required fields are not zero-ed
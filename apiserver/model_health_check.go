@@ -0,0 +1,35 @@
+/*
+ * Signify app API
+ *
+ * API to access and configure the signify app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// HealthCheck - Result of a single health check.
+type HealthCheck struct {
+
+	// Name of the checked dependency
+	Name string `json:"name,omitempty"`
+
+	// Configuration the check belongs to, if any
+	ConfigId *int64 `json:"configId,omitempty"`
+
+	Status string `json:"status,omitempty"`
+
+	// Details why the check is degraded
+	Message *string `json:"message,omitempty"`
+}
+
+// AssertHealthCheckRequired checks if the required fields are not zero-ed
+func AssertHealthCheckRequired(obj HealthCheck) error {
+	return nil
+}
+
+// AssertHealthCheckConstraints checks if the values respects the defined constraints
+func AssertHealthCheckConstraints(obj HealthCheck) error {
+	return nil
+}
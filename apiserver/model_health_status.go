@@ -0,0 +1,34 @@
+/*
+ * Signify app API
+ *
+ * API to access and configure the signify app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// HealthStatus - Overall health of the app with a breakdown of all checks.
+type HealthStatus struct {
+
+	// Overall status, `degraded` if at least one check is degraded
+	Status string `json:"status,omitempty"`
+
+	Checks []HealthCheck `json:"checks,omitempty"`
+}
+
+// AssertHealthStatusRequired checks if the required fields are not zero-ed
+func AssertHealthStatusRequired(obj HealthStatus) error {
+	for _, el := range obj.Checks {
+		if err := AssertHealthCheckRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertHealthStatusConstraints checks if the values respects the defined constraints
+func AssertHealthStatusConstraints(obj HealthStatus) error {
+	return nil
+}
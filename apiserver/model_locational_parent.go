@@ -0,0 +1,30 @@
+/*
+ * Signify app API
+ *
+ * API to access and configure the signify app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// LocationalParent - Existing Eliona asset representing an Interact site or building.
+type LocationalParent struct {
+
+	// Interact UUID of the site or building
+	Uuid string `json:"uuid,omitempty"`
+
+	// ID of the existing Eliona asset
+	AssetId int32 `json:"assetId,omitempty"`
+}
+
+// AssertLocationalParentRequired checks if the required fields are not zero-ed
+func AssertLocationalParentRequired(obj LocationalParent) error {
+	return nil
+}
+
+// AssertLocationalParentConstraints checks if the values respects the defined constraints
+func AssertLocationalParentConstraints(obj LocationalParent) error {
+	return nil
+}
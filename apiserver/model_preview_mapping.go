@@ -0,0 +1,30 @@
+/*
+ * Signify app API
+ *
+ * API to access and configure the signify app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// PreviewMapping - State of an asset in a project.
+type PreviewMapping struct {
+	ProjectId string `json:"projectId,omitempty"`
+
+	State string `json:"state,omitempty"`
+
+	// ID of the existing Eliona asset
+	AssetId *int32 `json:"assetId,omitempty"`
+}
+
+// AssertPreviewMappingRequired checks if the required fields are not zero-ed
+func AssertPreviewMappingRequired(obj PreviewMapping) error {
+	return nil
+}
+
+// AssertPreviewMappingConstraints checks if the values respects the defined constraints
+func AssertPreviewMappingConstraints(obj PreviewMapping) error {
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+ * Signify app API
+ *
+ * API to access and configure the signify app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// PreviewNode - Asset in the preview tree.
+type PreviewNode struct {
+	Kind string `json:"kind,omitempty"`
+
+	// Interact UUID of the object, empty for the root asset
+	Uuid string `json:"uuid,omitempty"`
+
+	// Name of the object
+	Name string `json:"name,omitempty"`
+
+	// Eliona asset type the asset is created with
+	AssetType string `json:"assetType,omitempty"`
+
+	// Global asset identifier of the Eliona asset
+	GlobalAssetId string `json:"globalAssetId,omitempty"`
+
+	// State of the asset in each project of the configuration
+	Mappings []PreviewMapping `json:"mappings,omitempty"`
+
+	Children []PreviewNode `json:"children,omitempty"`
+}
+
+// AssertPreviewNodeRequired checks if the required fields are not zero-ed
+func AssertPreviewNodeRequired(obj PreviewNode) error {
+	for _, el := range obj.Mappings {
+		if err := AssertPreviewMappingRequired(el); err != nil {
+			return err
+		}
+	}
+	for _, el := range obj.Children {
+		if err := AssertPreviewNodeRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertPreviewNodeConstraints checks if the values respects the defined constraints
+func AssertPreviewNodeConstraints(obj PreviewNode) error {
+	return nil
+}
@@ -0,0 +1,44 @@
+/*
+ * Signify app API
+ *
+ * API to access and configure the signify app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// ProjectBinding - Binds an Eliona project to the configuration with an own selection of assets.
+type ProjectBinding struct {
+
+	// Eliona project the assets are created in
+	ProjectId string `json:"projectId,omitempty"`
+
+	// Array of rules combined by logical OR, applied in addition to the asset filter of the configuration
+	AssetFilter [][]FilterRule `json:"assetFilter,omitempty"`
+
+	// Name of the root asset in the project. Defaults to `Signify`.
+	RootAssetName *string `json:"rootAssetName,omitempty"`
+
+	// Existing Eliona assets used instead of creating assets for sites or buildings
+	LocationalParents []LocationalParent `json:"locationalParents,omitempty"`
+}
+
+// AssertProjectBindingRequired checks if the required fields are not zero-ed
+func AssertProjectBindingRequired(obj ProjectBinding) error {
+	if err := AssertRecurseInterfaceRequired(obj.AssetFilter, AssertFilterRuleRequired); err != nil {
+		return err
+	}
+	for _, el := range obj.LocationalParents {
+		if err := AssertLocationalParentRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertProjectBindingConstraints checks if the values respects the defined constraints
+func AssertProjectBindingConstraints(obj ProjectBinding) error {
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+ * Signify app API
+ *
+ * API to access and configure the signify app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+import (
+	"time"
+)
+
+// SyncJob - Synchronisation of a configuration triggered by the API.
+type SyncJob struct {
+
+	// Identifier of the job
+	Id int64 `json:"id,omitempty"`
+
+	// Configuration which is synchronised
+	ConfigId int64 `json:"configId,omitempty"`
+
+	Status string `json:"status,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+
+	Result *SyncResult `json:"result,omitempty"`
+
+	// Reason why the synchronisation failed
+	Error *string `json:"error,omitempty"`
+}
+
+// AssertSyncJobRequired checks if the required fields are not zero-ed
+func AssertSyncJobRequired(obj SyncJob) error {
+	if obj.Result != nil {
+		if err := AssertSyncResultRequired(*obj.Result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertSyncJobConstraints checks if the values respects the defined constraints
+func AssertSyncJobConstraints(obj SyncJob) error {
+	return nil
+}
@@ -0,0 +1,38 @@
+/*
+ * Signify app API
+ *
+ * API to access and configure the signify app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// SyncPreview - Asset tree a synchronisation would create.
+type SyncPreview struct {
+
+	// Number of assets which would be created in all projects
+	NewAssets int32 `json:"newAssets,omitempty"`
+
+	// Number of assets which already exist in all projects
+	ExistingAssets int32 `json:"existingAssets,omitempty"`
+
+	// Top level assets, i.e. the root asset, the functional group of the sensors and attached sites and buildings
+	Nodes []PreviewNode `json:"nodes,omitempty"`
+}
+
+// AssertSyncPreviewRequired checks if the required fields are not zero-ed
+func AssertSyncPreviewRequired(obj SyncPreview) error {
+	for _, el := range obj.Nodes {
+		if err := AssertPreviewNodeRequired(el); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssertSyncPreviewConstraints checks if the values respects the defined constraints
+func AssertSyncPreviewConstraints(obj SyncPreview) error {
+	return nil
+}
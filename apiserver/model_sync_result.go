@@ -0,0 +1,33 @@
+/*
+ * Signify app API
+ *
+ * API to access and configure the signify app
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// SyncResult - Result of a synchronisation.
+type SyncResult struct {
+
+	// Number of sites, buildings, storeys and spaces passing the asset filter
+	DiscoveredObjects int32 `json:"discoveredObjects,omitempty"`
+
+	// Number of assets created in all projects
+	CreatedAssets int32 `json:"createdAssets,omitempty"`
+
+	// Number of assets deleted in all projects because their objects are excluded
+	RemovedAssets int32 `json:"removedAssets,omitempty"`
+}
+
+// AssertSyncResultRequired checks if the required fields are not zero-ed
+func AssertSyncResultRequired(obj SyncResult) error {
+	return nil
+}
+
+// AssertSyncResultConstraints checks if the values respects the defined constraints
+func AssertSyncResultConstraints(obj SyncResult) error {
+	return nil
+}
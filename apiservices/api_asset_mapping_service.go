@@ -0,0 +1,65 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package apiservices
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"signify/apiserver"
+	"signify/conf"
+)
+
+// AssetMappingApiService is a service that implements the logic for the AssetMappingApiServicer
+// This service should implement the business logic for every endpoint for the AssetMappingApi API.
+// Include any external packages or services that will be required by this service.
+type AssetMappingApiService struct {
+}
+
+// NewAssetMappingApiService creates a default api service
+func NewAssetMappingApiService() apiserver.AssetMappingAPIServicer {
+	return &AssetMappingApiService{}
+}
+
+func (s *AssetMappingApiService) GetAssetMappingsByConfigId(ctx context.Context, configId int64, kind string, projectId string, parentUuid string) (apiserver.ImplResponse, error) {
+	mappings, err := conf.GetAssetMappings(ctx, configId, kind, projectId, parentUuid)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, mappings), nil
+}
+
+func (s *AssetMappingApiService) GetAssetMappingById(ctx context.Context, assetMappingId int64) (apiserver.ImplResponse, error) {
+	mapping, err := conf.GetAssetMapping(ctx, assetMappingId)
+	if errors.Is(err, conf.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, mapping), nil
+}
+
+func (s *AssetMappingApiService) DeleteAssetMappingById(ctx context.Context, assetMappingId int64) (apiserver.ImplResponse, error) {
+	err := conf.DeleteAssetMapping(ctx, assetMappingId)
+	if errors.Is(err, conf.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.ImplResponse{Code: http.StatusNoContent}, nil
+}
@@ -18,9 +18,11 @@ package apiservices
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"signify/apiserver"
 	"signify/conf"
+	"signify/eliona"
 )
 
 // ConfigurationApiService is a service that implements the logic for the ConfigurationApiServicer
@@ -52,8 +54,8 @@ func (s *ConfigurationApiService) PostConfiguration(ctx context.Context, config
 
 func (s *ConfigurationApiService) GetConfigurationById(ctx context.Context, configId int64) (apiserver.ImplResponse, error) {
 	config, err := conf.GetConfig(ctx, configId)
-	if errors.Is(err, conf.ErrBadRequest) {
-		return apiserver.ImplResponse{Code: http.StatusBadRequest}, nil
+	if errors.Is(err, conf.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
 	}
 	if err != nil {
 		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
@@ -71,9 +73,19 @@ func (s *ConfigurationApiService) PutConfigurationById(ctx context.Context, conf
 }
 
 func (s *ConfigurationApiService) DeleteConfigurationById(ctx context.Context, configId int64) (apiserver.ImplResponse, error) {
-	err := conf.DeleteConfig(ctx, configId)
-	if errors.Is(err, conf.ErrBadRequest) {
-		return apiserver.ImplResponse{Code: http.StatusBadRequest}, nil
+	// the alarm rules are deleted in Eliona first, as their ids are lost with the configuration
+	alarmRules, err := conf.GetAlarmRules(ctx, configId)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	for _, alarmRule := range alarmRules {
+		if err := eliona.DeleteAlarmRule(alarmRule.AlarmRuleID); err != nil {
+			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, fmt.Errorf("deleting alarm rule %d in Eliona: %w", alarmRule.AlarmRuleID, err)
+		}
+	}
+	err = conf.DeleteConfig(ctx, configId)
+	if errors.Is(err, conf.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
 	}
 	if err != nil {
 		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
@@ -17,8 +17,10 @@ package apiservices
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"signify/apiserver"
+	"signify/conf"
 	"signify/eliona"
 )
 
@@ -34,32 +36,33 @@ func NewCustomizationApiService() apiserver.CustomizationAPIServicer {
 }
 
 // GetDashboardTemplateByName - Get a full dashboard template
-func (s *CustomizationApiService) GetDashboardTemplateByName(ctx context.Context, dashboardTemplateName string, projectId string) (apiserver.ImplResponse, error) {
-	if dashboardTemplateName == "Signify People Count" {
-		dashboard, err := eliona.SignifyPeopleCountDashboard(projectId)
-		if err != nil {
-			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+func (s *CustomizationApiService) GetDashboardTemplateByName(ctx context.Context, dashboardTemplateName string, projectId string, siteUuid string, buildingUuid string, assetId int32) (apiserver.ImplResponse, error) {
+	scopes := 0
+	for _, given := range []bool{siteUuid != "", buildingUuid != "", assetId != 0} {
+		if given {
+			scopes++
 		}
-		return apiserver.Response(http.StatusOK, dashboard), nil
-	} else if dashboardTemplateName == "Signify Occupancy" {
-		dashboard, err := eliona.SignifyOccupancyDashboard(projectId)
-		if err != nil {
-			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
-		}
-		return apiserver.Response(http.StatusOK, dashboard), nil
-	} else if dashboardTemplateName == "Signify Temperature" {
-		dashboard, err := eliona.SignifyTemperatureDashboard(projectId)
-		if err != nil {
-			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
-		}
-		return apiserver.Response(http.StatusOK, dashboard), nil
-	} else if dashboardTemplateName == "Signify Humidity" {
-		dashboard, err := eliona.SignifyHumidityDashboard(projectId)
-		if err != nil {
-			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
-		}
-		return apiserver.Response(http.StatusOK, dashboard), nil
-	} else {
+	}
+	if scopes > 1 {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, nil
+	}
+	scope := eliona.DashboardScope{SiteUUID: siteUuid, BuildingUUID: buildingUuid, AssetId: assetId}
+
+	templates, err := eliona.DashboardTemplates()
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	template, ok := templates[dashboardTemplateName]
+	if !ok {
 		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
 	}
+
+	dashboard, err := eliona.BuildDashboard(template, projectId, scope)
+	if errors.Is(err, conf.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, dashboard), nil
 }
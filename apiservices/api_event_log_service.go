@@ -0,0 +1,43 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package apiservices
+
+import (
+	"context"
+	"net/http"
+	"signify/apiserver"
+	"signify/conf"
+	"time"
+)
+
+// EventLogApiService is a service that implements the logic for the EventLogApiServicer
+// This service should implement the business logic for every endpoint for the EventLogApi API.
+// Include any external packages or services that will be required by this service.
+type EventLogApiService struct {
+}
+
+// NewEventLogApiService creates a default api service
+func NewEventLogApiService() apiserver.EventLogAPIServicer {
+	return &EventLogApiService{}
+}
+
+func (s *EventLogApiService) GetEvents(ctx context.Context, configId int64, since time.Time, cursor int64, limit int32) (apiserver.ImplResponse, error) {
+	events, err := conf.GetEvents(ctx, configId, since, cursor, limit)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, events), nil
+}
@@ -0,0 +1,98 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package apiservices
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"signify/apiserver"
+	"signify/conf"
+	"signify/eliona"
+	"signify/leader"
+	"signify/signify"
+)
+
+const (
+	healthOk       = "ok"
+	healthDegraded = "degraded"
+)
+
+// HealthApiService is a service that implements the logic for the HealthApiServicer
+// This service should implement the business logic for every endpoint for the HealthApi API.
+// Include any external packages or services that will be required by this service.
+type HealthApiService struct {
+}
+
+// NewHealthApiService creates a default api service
+func NewHealthApiService() apiserver.HealthAPIServicer {
+	return &HealthApiService{}
+}
+
+// GetLiveness - Liveness of the app
+func (s *HealthApiService) GetLiveness(ctx context.Context) (apiserver.ImplResponse, error) {
+	return apiserver.Response(http.StatusOK, apiserver.HealthStatus{Status: healthOk}), nil
+}
+
+// GetReadiness - Readiness of the app
+func (s *HealthApiService) GetReadiness(ctx context.Context) (apiserver.ImplResponse, error) {
+	var checks []apiserver.HealthCheck
+	checks = append(checks, healthCheck("database", nil, conf.PingDatabase(ctx)))
+	checks = append(checks, healthCheck("eliona-api", nil, eliona.CheckApi(ctx)))
+
+	configs, err := conf.GetConfigs(ctx)
+	if err != nil {
+		checks = append(checks, healthCheck("configurations", nil, err))
+	}
+	for _, config := range configs {
+		if !conf.IsConfigEnabled(config) {
+			continue
+		}
+		checks = append(checks, healthCheck("token", config.Id, signify.TokenStatus(config)))
+		// the websockets are only opened by the instance leading the configuration
+		if !leader.IsLeader(*config.Id) {
+			continue
+		}
+		var subscriptionErr error
+		if signify.OpenSubscriptions(*config.Id) == 0 {
+			subscriptionErr = errors.New("no open websocket subscription")
+		}
+		checks = append(checks, healthCheck("websocket", config.Id, subscriptionErr))
+	}
+
+	health := apiserver.HealthStatus{Status: healthOk, Checks: checks}
+	for _, check := range checks {
+		if check.Status != healthOk {
+			health.Status = healthDegraded
+			return apiserver.Response(http.StatusServiceUnavailable, health), nil
+		}
+	}
+	return apiserver.Response(http.StatusOK, health), nil
+}
+
+func healthCheck(name string, configId *int64, err error) apiserver.HealthCheck {
+	check := apiserver.HealthCheck{
+		Name:     name,
+		ConfigId: configId,
+		Status:   healthOk,
+	}
+	if err != nil {
+		message := err.Error()
+		check.Status = healthDegraded
+		check.Message = &message
+	}
+	return check
+}
@@ -0,0 +1,88 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package apiservices
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"signify/apiserver"
+	"signify/conf"
+)
+
+// PreviewFunc discovers the objects of a configuration and returns the asset tree a synchronisation would create
+type PreviewFunc func(config apiserver.Configuration) (apiserver.SyncPreview, error)
+
+// SynchronizationApiService is a service that implements the logic for the SynchronizationApiServicer
+// This service should implement the business logic for every endpoint for the SynchronizationApi API.
+// Include any external packages or services that will be required by this service.
+type SynchronizationApiService struct {
+	preview PreviewFunc
+}
+
+// NewSynchronizationApiService creates a default api service
+func NewSynchronizationApiService(preview PreviewFunc) apiserver.SynchronizationAPIServicer {
+	return &SynchronizationApiService{
+		preview: preview,
+	}
+}
+
+// PostSyncByConfigId enqueues a synchronisation job. The job is stored in the database, so it is run by the
+// instance leading the configuration and can be polled on every instance.
+func (s *SynchronizationApiService) PostSyncByConfigId(ctx context.Context, configId int64) (apiserver.ImplResponse, error) {
+	config, err := conf.GetConfig(ctx, configId)
+	if errors.Is(err, conf.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	if !conf.IsConfigEnabled(*config) {
+		return apiserver.ImplResponse{Code: http.StatusConflict}, nil
+	}
+
+	job, err := conf.InsertSyncJob(ctx, configId)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusAccepted, job), nil
+}
+
+func (s *SynchronizationApiService) GetSyncJobById(ctx context.Context, syncJobId int64) (apiserver.ImplResponse, error) {
+	job, err := conf.GetSyncJob(ctx, syncJobId)
+	if errors.Is(err, conf.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, *job), nil
+}
+
+func (s *SynchronizationApiService) GetSyncPreviewByConfigId(ctx context.Context, configId int64) (apiserver.ImplResponse, error) {
+	config, err := conf.GetConfig(ctx, configId)
+	if errors.Is(err, conf.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	}
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	preview, err := s.preview(*config)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, preview), nil
+}
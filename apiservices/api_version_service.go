@@ -22,9 +22,9 @@ import (
 	"net/http"
 	"os"
 	"signify/apiserver"
+	"signify/logging"
 
 	"github.com/eliona-smart-building-assistant/go-utils/common"
-	"github.com/eliona-smart-building-assistant/go-utils/log"
 	"gopkg.in/yaml.v3"
 )
 
@@ -43,17 +43,17 @@ func NewVersionApiService() apiserver.VersionAPIServicer {
 func (s *VersionApiService) GetOpenAPI(ctx context.Context) (apiserver.ImplResponse, error) {
 	bytes, err := os.ReadFile("openapi.yaml")
 	if err != nil {
-		log.Error("services", "%s: %v", "GetOpenAPI", err)
+		logging.Tag("services").Error("Error reading OpenAPI specification", "error", err)
 		return apiserver.ImplResponse{Code: http.StatusNotFound}, err
 	}
 	var body interface{}
 	err = yaml.Unmarshal(bytes, &body)
 	if err != nil {
-		log.Error("services", "%s: %v", "GetOpenAPI", err)
+		logging.Tag("services").Error("Error reading OpenAPI specification", "error", err)
 		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
 	}
 	if err := tryJSONEncoding(body); err != nil {
-		log.Error("services", "openapi file not encodable to JSON: %v", err)
+		logging.Tag("services").Error("OpenAPI specification not encodable to JSON", "error", err)
 		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, nil
 	}
 	return apiserver.Response(http.StatusOK, body), nil
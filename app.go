@@ -17,27 +17,36 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
 	"github.com/eliona-smart-building-assistant/go-eliona/app"
 	"github.com/eliona-smart-building-assistant/go-eliona/asset"
-	"github.com/eliona-smart-building-assistant/go-eliona/client"
+	"github.com/eliona-smart-building-assistant/go-eliona/dashboard"
 	"github.com/eliona-smart-building-assistant/go-eliona/frontend"
 	"github.com/eliona-smart-building-assistant/go-utils/db"
 	"github.com/volatiletech/null/v8"
 	"net/http"
+	"reflect"
+	"signify/analytics"
 	"signify/apiserver"
 	"signify/apiservices"
 	"signify/appdb"
 	"signify/conf"
 	"signify/eliona"
+	"signify/events"
+	"signify/leader"
+	"signify/logging"
+	"signify/metrics"
+	"signify/notification"
 	"signify/signify"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/eliona-smart-building-assistant/go-utils/common"
 	utilshttp "github.com/eliona-smart-building-assistant/go-utils/http"
-	"github.com/eliona-smart-building-assistant/go-utils/log"
 )
 
 func initialization() {
@@ -51,20 +60,33 @@ func initialization() {
 	app.Init(conn, app.AppName(),
 		app.ExecSqlFile("conf/init.sql"),
 		asset.InitAssetTypeFiles("eliona/*-asset-type.json"),
+		dashboard.InitWidgetTypeFiles("eliona/*-widget-type.json"),
 	)
 
 	// Patch the app to v1.1.0
 	app.Patch(conn, app.AppName(), "010100",
 		app.ExecSqlFile("conf/v1.1.0.sql"),
 	)
+
+	// Patch the app to v1.2.0
+	app.Patch(conn, app.AppName(), "010200",
+		app.ExecSqlFile("conf/v1.2.0.sql"),
+		asset.InitAssetTypeFiles("eliona/*-asset-type.json"),
+		dashboard.InitWidgetTypeFiles("eliona/*-widget-type.json"),
+	)
 }
 
 func collectAssets() {
 	configs, err := conf.GetConfigs(context.Background())
 	if err != nil {
-		log.Fatal("conf", "Couldn't read configs from DB: %v", err)
+		logging.Fatal(logging.Tag("conf"), "Couldn't read configs from DB", "error", err)
 		return
 	}
+	var configIds []int64
+	for _, config := range configs {
+		configIds = append(configIds, *config.Id)
+	}
+	leader.ReleaseExcept(context.Background(), configIds)
 	if len(configs) == 0 {
 		return
 	}
@@ -73,189 +95,634 @@ func collectAssets() {
 
 		// Skip config if disabled and set inactive
 		if !conf.IsConfigEnabled(config) {
+			leader.Release(context.Background(), *config.Id)
 			if conf.IsConfigActive(config) {
 				_, err := conf.SetConfigActiveState(context.Background(), config, false)
 				if err != nil {
-					log.Fatal("conf", "Couldn't set config active state to DB: %v", err)
+					logging.Fatal(logging.Config("conf", *config.Id), "Couldn't set config active state to DB", "error", err)
 					return
 				}
+				// jobs enqueued before the configuration was disabled are never run, as no instance leads it
+				if err := conf.FailPendingSyncJobs(context.Background(), *config.Id, "configuration is disabled"); err != nil {
+					logging.Config("sync", *config.Id).Error("Error failing pending sync jobs", "error", err)
+				}
 			}
 			continue
 		}
 
+		// Skip config if led by another instance
+		if !lead(config) {
+			continue
+		}
+
 		// Signals that this config is active
 		if !conf.IsConfigActive(config) {
 			_, err := conf.SetConfigActiveState(context.Background(), config, true)
 			if err != nil {
-				log.Fatal("conf", "Couldn't set config active state to DB: %v", err)
+				logging.Fatal(logging.Config("conf", *config.Id), "Couldn't set config active state to DB", "error", err)
 				return
 			}
-			log.Debug("conf", "Collecting initialized with Configuration %d:\n"+
-				"Enable: %t\n"+
-				"Refresh Interval: %d\n"+
-				"Request Timeout: %d\n"+
-				"Active: %t\n"+
-				"Project IDs: %v\n",
-				*config.Id,
-				*config.Enable,
-				config.RefreshInterval,
-				*config.RequestTimeout,
-				*config.Active,
-				*config.ProjectIDs)
+			logging.Config("conf", *config.Id).Debug("Collecting initialized",
+				"enable", *config.Enable,
+				"refresh_interval", config.RefreshInterval,
+				"request_timeout", *config.RequestTimeout,
+				"active", *config.Active,
+				"project_ids", *config.ProjectIDs)
 		}
 
 		common.RunOnceWithParam(func(config apiserver.Configuration) {
-
-			log.Info("main", "Start collecting for configuration id %d", *config.Id)
-			spaces, err := collectObjects(config)
-			if err != nil {
-				log.Error("collect", "Error collect spaces: %v", err)
+			if _, err := synchronize(config); err != nil {
+				logging.Config("main", *config.Id).Error("Error synchronizing configuration", "error", err)
 				return
 			}
 
-			if config.ProjectIDs != nil && len(*config.ProjectIDs) > 0 {
+			time.Sleep(time.Second * time.Duration(config.RefreshInterval))
+		}, config, *config.Id)
+	}
 
-				for _, projectId := range *config.ProjectIDs {
-					countCreated, err := createAssets(config, projectId, spaces)
-					if err != nil {
-						log.Error("send", "Error sending assets: %v", err)
-						return
-					}
+}
 
-					if countCreated > 0 && config.UserId != nil {
-						err := notifyUser(*config.UserId, projectId, countCreated)
-						if err != nil {
-							log.Error("collect", "Error notifying users about CAC: %v", err)
-						}
-					}
-				}
+// lead returns true if this instance leads the configuration. If the leadership is lost, the subscriptions are
+// stopped and the state of the spaces is dropped, because the new leader streams the data from now on.
+func lead(config apiserver.Configuration) bool {
+	wasLeading := leader.IsLeader(*config.Id)
+	leading, err := leader.Lead(context.Background(), *config.Id)
+	if err != nil {
+		logging.Config("leader", *config.Id).Error("Error checking leadership", "error", err)
+	}
+	if !wasLeading && leading {
+		// jobs started by a previous leader are never finished by it
+		if err := conf.RequeueRunningSyncJobs(context.Background(), *config.Id); err != nil {
+			logging.Config("sync", *config.Id).Error("Error requeueing running sync jobs", "error", err)
+		}
+	}
+	if wasLeading && !leading {
+		// a running synchronization can't subscribe again, as it checks the leadership with the same lock
+		subscriptionsMutex.Lock()
+		signify.CloseExistingSubscriptions(config)
+		subscriptionsMutex.Unlock()
+		for _, subject := range events.Running(*config.Id) {
+			events.RecordStopped(*config.Id, subject, events.SubscriptionStoppedEventType, "Subscription %s stopped, the configuration is led by another instance", subject)
+		}
+		analytics.SetSpaces(*config.Id, nil)
+	}
+	return leading
+}
 
+// runSyncJobs runs the synchronisation jobs enqueued with the API for the configurations led by this instance. The
+// jobs of other configurations are left to their leaders, and disabled configurations are not led by any instance.
+func runSyncJobs() {
+	ctx := context.Background()
+	for _, configId := range leader.Leading() {
+		logger := logging.Config("sync", configId)
+		jobIds, err := conf.StartPendingSyncJobs(ctx, configId)
+		if err != nil {
+			logger.Error("Error starting pending sync jobs", "error", err)
+			continue
+		}
+		if len(jobIds) == 0 {
+			continue
+		}
+		config, err := conf.GetConfig(ctx, configId)
+		if err == nil && !conf.IsConfigEnabled(*config) {
+			err = errors.New("configuration is disabled")
+		}
+		if err != nil {
+			if err := conf.FinishSyncJobs(ctx, jobIds, apiserver.SyncResult{}, err); err != nil {
+				logger.Error("Error storing result of sync jobs", "sync_job_ids", jobIds, "error", err)
+			}
+			continue
+		}
+		go func(config apiserver.Configuration) {
+			logger.Info("Start synchronisation jobs", "sync_job_ids", jobIds)
+			result, err := synchronize(config)
+			if err != nil {
+				logger.Error("Synchronisation jobs failed", "sync_job_ids", jobIds, "error", err)
 			} else {
+				logger.Info("Finished synchronisation jobs", "sync_job_ids", jobIds)
+			}
+			if err := conf.FinishSyncJobs(ctx, jobIds, result, err); err != nil {
+				logger.Error("Error storing result of sync jobs", "sync_job_ids", jobIds, "error", err)
+			}
+		}(*config)
+	}
+}
+
+// syncJobRetention defines how long finished jobs can be polled
+const syncJobRetention = time.Hour
+
+// removeExpiredSyncJobs deletes the jobs finished longer ago than the retention
+func removeExpiredSyncJobs() {
+	if _, err := conf.DeleteSyncJobsBefore(context.Background(), time.Now().Add(-syncJobRetention)); err != nil {
+		logging.Tag("sync").Error("Error removing expired sync jobs", "error", err)
+	}
+}
+
+// synchronization is a running synchronization of a configuration
+type synchronization struct {
+	done   chan struct{}
+	result apiserver.SyncResult
+	err    error
+}
+
+var synchronizations = make(map[int64]*synchronization)
+var synchronizationsMutex sync.Mutex
+
+// synchronize collects the objects of a configuration, creates the assets and refreshes the subscriptions.
+// If a synchronization of the configuration is already running, it waits for it and returns its result.
+func synchronize(config apiserver.Configuration) (apiserver.SyncResult, error) {
+	synchronizationsMutex.Lock()
+	if running, ok := synchronizations[*config.Id]; ok {
+		synchronizationsMutex.Unlock()
+		logging.Config("main", *config.Id).Info("Waiting for running synchronization")
+		<-running.done
+		return running.result, running.err
+	}
+	current := &synchronization{done: make(chan struct{})}
+	synchronizations[*config.Id] = current
+	synchronizationsMutex.Unlock()
+
+	current.result, current.err = runSynchronization(config)
+	notification.Send(config)
+
+	synchronizationsMutex.Lock()
+	delete(synchronizations, *config.Id)
+	synchronizationsMutex.Unlock()
+	close(current.done)
+
+	return current.result, current.err
+}
+
+func runSynchronization(config apiserver.Configuration) (apiserver.SyncResult, error) {
+	var result apiserver.SyncResult
+
+	logger := logging.Config("main", *config.Id)
+	logger.Info("Start collecting")
+	start := time.Now()
+	spaces, err := collectObjects(config, true)
+	metrics.ObserveDiscovery(*config.Id, time.Since(start))
+	if err != nil {
+		notification.Failed(*config.Id, notification.DiscoveryFailure, "", err)
+		events.Record(*config.Id, events.DiscoveryFailedEventType, "Discovery failed: %v", err)
+		return result, fmt.Errorf("collecting spaces: %w", err)
+	}
+	notification.Recovered(*config.Id, notification.DiscoveryFailure, "")
+	result.DiscoveredObjects = countObjects(spaces)
+	events.Record(*config.Id, events.DiscoveryEventType, "Discovered %d objects in %s", result.DiscoveredObjects, time.Since(start).Round(time.Millisecond))
 
-				log.Info("eliona", "No project id defined in configuration %d. No data is send to Eliona.", config.Id)
+	if bindings := conf.ProjectBindings(config); len(bindings) > 0 {
 
+		alarmTemplates, templatesChanged := alarmTemplatesChanged(config)
+		for _, binding := range bindings {
+			projectSpaces, err := signify.FilterObjects(spaces, binding.AssetFilter)
+			if err != nil {
+				return result, fmt.Errorf("filtering spaces for project %s: %w", binding.ProjectId, err)
+			}
+			countCreated, err := createAssets(config, binding, projectSpaces, templatesChanged)
+			result.CreatedAssets += int32(countCreated)
+			if err != nil {
+				notification.Failed(*config.Id, notification.AssetCreationFailure, binding.ProjectId, err)
+				return result, fmt.Errorf("sending assets: %w", err)
 			}
-			log.Info("main", "Finished collecting for configuration id %d successfully", *config.Id)
+			notification.Recovered(*config.Id, notification.AssetCreationFailure, binding.ProjectId)
+		}
+		setAlarmTemplatesProvisioned(config, alarmTemplates)
+
+	} else {
 
-			log.Info("main", "(Re)starting subscriptions and resubscribe all")
-			subscribeData(config)
+		logger.Info("No project id defined in configuration. No data is send to Eliona.")
 
-			time.Sleep(time.Second * time.Duration(config.RefreshInterval))
-		}, config, *config.Id)
 	}
 
+	countRemoved, err := removeExcludedAssets(config)
+	result.RemovedAssets = countRemoved
+	if err != nil {
+		return result, fmt.Errorf("removing excluded assets: %w", err)
+	}
+	if err := removeObsoleteAlarmRules(config); err != nil {
+		return result, fmt.Errorf("removing obsolete alarm rules: %w", err)
+	}
+	updateMappedAssetsMetrics(config)
+	updateAnalyticsSpaces(config)
+	logger.Info("Finished collecting successfully")
+
+	logger.Info("(Re)starting subscriptions and resubscribe all")
+	subscribeData(config)
+
+	return result, nil
 }
 
-// createAssets creates the complete asset tree, if the asset doesn't already exist
-func createAssets(config apiserver.Configuration, projectId string, spaces []signify.Object) (int, error) {
-	var countCreated = 0
+// removeExcludedAssets deletes the assets of excluded objects and their descendants in Eliona and
+// removes their mappings. Descendants are deleted before their parents.
+func removeExcludedAssets(config apiserver.Configuration) (int32, error) {
+	var countRemoved int32
+	excludedUUIDs := conf.ExcludedUUIDs(config)
+	if len(excludedUUIDs) == 0 {
+		return countRemoved, nil
+	}
 
-	rootAssetId, created, err := createAsset(config, projectId, eliona.RootAssetType, nil, nil, eliona.RootAssetType, conf.RootAssetKind, "Signify")
+	mappings, err := conf.GetAssets(context.Background(),
+		appdb.AssetWhere.ConfigurationID.EQ(*config.Id),
+	)
 	if err != nil {
-		return countCreated, fmt.Errorf("create root asset first time: %w", err)
+		return countRemoved, fmt.Errorf("getting asset mappings: %w", err)
 	}
-	if created {
-		countCreated++
+
+	excluded := make(map[string]bool)
+	for _, uuid := range excludedUUIDs {
+		excluded[uuid] = true
 	}
+	for found := true; found; {
+		found = false
+		for _, mapping := range mappings {
+			if !excluded[mapping.UUID] && mapping.ParentUUID.Valid && excluded[mapping.ParentUUID.String] {
+				excluded[mapping.UUID] = true
+				found = true
+			}
+		}
+	}
+
+	for _, kind := range []conf.AssetKind{conf.SpaceAssetKind, conf.StoreyAssetKind, conf.BuildingAssetKind, conf.SiteAssetKind} {
+		for _, mapping := range mappings {
+			if mapping.Kind != string(kind) || !excluded[mapping.UUID] {
+				continue
+			}
+			// assets not created by the app are never deleted
+			if mapping.AssetID.Valid && !mapping.Attached {
+				if err := eliona.DeleteAsset(mapping.AssetID.Int32); err != nil {
+					return countRemoved, fmt.Errorf("deleting asset %d in Eliona: %w", mapping.AssetID.Int32, err)
+				}
+			}
+			if err := conf.DeleteAssetMapping(context.Background(), mapping.ID); err != nil {
+				return countRemoved, fmt.Errorf("deleting mapping for %s: %w", mapping.GlobalAssetID, err)
+			}
+			logging.Config("assets", *config.Id).Info("Removed excluded asset", logging.ProjectId(mapping.ProjectID),
+				"global_asset_id", mapping.GlobalAssetID, "asset_id", mapping.AssetID.Int32)
+			countRemoved++
+			var building string
+			if kind == conf.BuildingAssetKind {
+				building = mapping.Name.String
+			}
+			notification.Removed(*config.Id, mapping.ProjectID, kind, building)
+			events.Record(*config.Id, events.AssetRemovedEventType, "Removed %s asset %s with id %d in project %s", kind, mapping.GlobalAssetID, mapping.AssetID.Int32, mapping.ProjectID)
+		}
+	}
+	return countRemoved, nil
+}
 
-	for _, site := range spaces {
+// countObjects counts the objects including all children
+func countObjects(objects []signify.Object) int32 {
+	var count int32
+	for _, object := range objects {
+		count += 1 + countObjects(object.Children)
+	}
+	return count
+}
 
-		siteAssetId, created, err := createAsset(config, projectId, site.Uuid, nil, &rootAssetId, eliona.GroupAssetType, conf.SiteAssetKind, site.Name)
-		if err != nil {
-			return countCreated, fmt.Errorf("create site asset first time: %w", err)
+// spaceSubscriptionTypes maps the asset types of spaces to the subscription type they report values for
+var spaceSubscriptionTypes = map[string]signify.SubscriptionType{
+	eliona.OccupancyAssetType:   signify.OccupancySubscriptionType,
+	eliona.PeopleCountAssetType: signify.PeopleCountSubscriptionType,
+	eliona.TemperatureAssetType: signify.TemperatureSubscriptionType,
+	eliona.HumidityAssetType:    signify.HumiditySubscriptionType,
+}
+
+// updateAnalyticsSpaces passes the space assets with their storey and building assets and their capacity to analytics.
+// Attached storey or building assets are left out, so aggregates are only written to assets created by the app.
+func updateAnalyticsSpaces(config apiserver.Configuration) {
+	mappings, err := conf.GetAssets(context.Background(),
+		appdb.AssetWhere.ConfigurationID.EQ(*config.Id),
+	)
+	if err != nil {
+		logging.Config("analytics", *config.Id).Error("Error getting asset mappings", "error", err)
+		return
+	}
+	mappingsByUUID := make(map[string]*appdb.Asset)
+	for _, mapping := range mappings {
+		mappingsByUUID[mapping.ProjectID+"/"+mapping.UUID] = mapping
+	}
+	parentAssetId := func(mapping *appdb.Asset) (*appdb.Asset, *int32) {
+		if mapping == nil || !mapping.ParentUUID.Valid {
+			return nil, nil
 		}
-		if created {
-			countCreated++
+		parent, ok := mappingsByUUID[mapping.ProjectID+"/"+mapping.ParentUUID.String]
+		if !ok {
+			return nil, nil
 		}
+		// no data is written to assets attached from the customer's own hierarchy
+		if !parent.AssetID.Valid || parent.Attached {
+			return parent, nil
+		}
+		return parent, common.Ptr(parent.AssetID.Int32)
+	}
 
-		for _, building := range site.Children {
+	var topology []analytics.Space
+	spaceDetailsMutex.Lock()
+	for _, mapping := range mappings {
+		if mapping.Kind != string(conf.SpaceAssetKind) || !mapping.AssetID.Valid {
+			continue
+		}
+		space := analytics.Space{AssetId: mapping.AssetID.Int32}
+		if subscriptionType, ok := spaceSubscriptionTypes[mapping.AssetType.String]; ok {
+			space.SubscriptionType = subscriptionType
+		}
+		var storey *appdb.Asset
+		storey, space.StoreyAssetId = parentAssetId(mapping)
+		_, space.BuildingAssetId = parentAssetId(storey)
+		if details, ok := spaceDetails[mapping.AssetID.Int32]; ok {
+			space.Capacity = details.Capacity
+		}
+		topology = append(topology, space)
+	}
+	spaceDetailsMutex.Unlock()
+	analytics.SetSpaces(*config.Id, topology)
+}
+
+// updateMappedAssetsMetrics publishes the number of mapped assets per kind
+func updateMappedAssetsMetrics(config apiserver.Configuration) {
+	counts, err := conf.CountAssetsByKind(context.Background(), *config.Id)
+	if err != nil {
+		logging.Config("metrics", *config.Id).Error("Error counting mapped assets", "error", err)
+		return
+	}
+	metrics.ResetMappedAssets(*config.Id)
+	for kind, count := range counts {
+		metrics.SetMappedAssets(*config.Id, string(kind), count)
+	}
+}
+
+// createAssets creates the complete asset tree of a project binding, if the asset doesn't already exist.
+// Sites and buildings attached to existing assets are not created. The root asset is only created if needed.
+func createAssets(config apiserver.Configuration, binding apiserver.ProjectBinding, spaces []signify.Object, provisionAlarms bool) (int, error) {
+	var countCreated = 0
+	projectId := binding.ProjectId
 
-			buildingAssetId, created, err := createAsset(config, projectId, building.Uuid, common.Ptr(site.Uuid), &siteAssetId, eliona.GroupAssetType, conf.BuildingAssetKind, building.Name)
+	assetIds := make(map[*plannedAsset]int32)
+	var functionalGroupId *int32
+	for _, planned := range planAssets(binding, spaces) {
+
+		if planned.attachTo != nil {
+			assetId, err := attachAsset(config, projectId, planned.identifier, planned.parentIdentifier, planned.assetType, planned.kind, *planned.attachTo)
 			if err != nil {
-				return countCreated, fmt.Errorf("create building asset first time: %w", err)
+				return countCreated, fmt.Errorf("attach %s asset: %w", planned.kind, err)
 			}
-			if created {
-				countCreated++
+			assetIds[planned] = assetId
+			continue
+		}
+
+		var parentId *int32
+		if planned.parent != nil {
+			parentId = common.Ptr(assetIds[planned.parent])
+		}
+		var metadata eliona.AssetMetadata
+		if planned.object != nil {
+			var functionalParentId *int32
+			if planned.kind == conf.SpaceAssetKind {
+				functionalParentId = functionalGroupId
 			}
+			metadata = assetMetadata(*planned.object, functionalParentId)
+		}
+		assetId, created, err := createAsset(config, projectId, planned.identifier, planned.parentIdentifier, parentId, planned.assetType, planned.kind, planned.name, metadata)
+		if err != nil {
+			return countCreated, fmt.Errorf("create %s asset first time: %w", planned.kind, err)
+		}
+		assetIds[planned] = assetId
+		if planned.kind == conf.FunctionalGroupAssetKind {
+			functionalGroupId = &assetId
+		}
+		if created || provisionAlarms {
+			provisionAlarmRules(config, assetId, planned.assetType)
+		}
+		if created {
+			countCreated++
+			notification.Created(*config.Id, projectId, planned.kind, planned.building)
+		}
+		if planned.kind == conf.SpaceAssetKind && upsertSpaceDetails(config, assetId, *planned.object) && !created {
+			notification.Updated(*config.Id, projectId, conf.SpaceAssetKind, planned.building)
+			events.Record(*config.Id, events.AssetUpdatedEventType, "Updated details of space asset %s with id %d in project %s", planned.name, assetId, projectId)
+		}
+	}
 
-			for _, storey := range building.Children {
+	return countCreated, nil
+}
 
-				storeyAssetId, created, err := createAsset(config, projectId, storey.Uuid, common.Ptr(building.Uuid), &buildingAssetId, eliona.GroupAssetType, conf.StoreyAssetKind, storey.Name)
-				if err != nil {
-					return countCreated, fmt.Errorf("create storey asset first time: %w", err)
-				}
-				if created {
-					countCreated++
-				}
+// plannedAsset is an asset a synchronisation creates or attaches for a project binding
+type plannedAsset struct {
+	kind             conf.AssetKind
+	identifier       string
+	parentIdentifier *string
+	assetType        string
+	name             string
+	building         string          // name of the building the asset belongs to
+	object           *signify.Object // nil for the root and the functional group
+	attachTo         *int32          // existing asset the object is attached to
+	parent           *plannedAsset   // locational parent, nil if the asset has none
+}
 
-				for _, space := range storey.Children {
+// planAssets returns the assets a synchronisation creates or attaches for a binding, parents before their children.
+// The synchronisation and the preview both follow this plan, so the preview shows what a synchronisation does.
+func planAssets(binding apiserver.ProjectBinding, sites []signify.Object) []*plannedAsset {
+	locationalParents := conf.LocationalParents(binding)
+	attachTo := func(uuid string) *int32 {
+		if assetId, ok := locationalParents[uuid]; ok {
+			return &assetId
+		}
+		return nil
+	}
 
-					if space.SpaceType == signify.OccupancySpaceType {
-						_, created, err := createAsset(config, projectId, space.Uuid, common.Ptr(storey.Uuid), &storeyAssetId, eliona.OccupancyAssetType, conf.SpaceAssetKind, space.Name)
-						if err != nil {
-							return countCreated, fmt.Errorf("create space asset first time: %w", err)
-						}
-						if created {
-							countCreated++
-						}
-					}
-					if space.SpaceType == signify.PeopleCountSpaceType {
-						_, created, err := createAsset(config, projectId, space.Uuid, common.Ptr(storey.Uuid), &storeyAssetId, eliona.PeopleCountAssetType, conf.SpaceAssetKind, space.Name)
-						if err != nil {
-							return countCreated, fmt.Errorf("create space asset first time: %w", err)
-						}
-						if created {
-							countCreated++
-						}
-					}
-					if space.SpaceType == signify.TemperatureSpaceType {
-						_, created, err := createAsset(config, projectId, space.Uuid, common.Ptr(storey.Uuid), &storeyAssetId, eliona.TemperatureAssetType, conf.SpaceAssetKind, space.Name)
-						if err != nil {
-							return countCreated, fmt.Errorf("create space asset first time: %w", err)
-						}
-						if created {
-							countCreated++
-						}
+	var plan []*plannedAsset
+	var root, functionalGroup *plannedAsset
+	for siteIdx := range sites {
+		site := &sites[siteIdx]
+
+		var siteAsset *plannedAsset
+		if assetId := attachTo(site.Uuid); assetId != nil {
+			siteAsset = &plannedAsset{kind: conf.SiteAssetKind, identifier: site.Uuid, assetType: eliona.GroupAssetType, name: site.Name, object: site, attachTo: assetId}
+			plan = append(plan, siteAsset)
+		} else if !buildingsAttached(*site, locationalParents) {
+			if root == nil {
+				root = &plannedAsset{kind: conf.RootAssetKind, identifier: eliona.RootAssetType, assetType: eliona.RootAssetType, name: conf.RootAssetName(binding)}
+				plan = append(plan, root)
+			}
+			siteAsset = &plannedAsset{kind: conf.SiteAssetKind, identifier: site.Uuid, assetType: eliona.GroupAssetType, name: site.Name, object: site, parent: root}
+			plan = append(plan, siteAsset)
+		}
+
+		for buildingIdx := range site.Children {
+			building := &site.Children[buildingIdx]
+			buildingAsset := &plannedAsset{kind: conf.BuildingAssetKind, identifier: building.Uuid, parentIdentifier: &site.Uuid, assetType: eliona.GroupAssetType, name: building.Name, building: building.Name, object: building, attachTo: attachTo(building.Uuid)}
+			if buildingAsset.attachTo == nil {
+				buildingAsset.parent = siteAsset
+			}
+			plan = append(plan, buildingAsset)
+
+			for storeyIdx := range building.Children {
+				storey := &building.Children[storeyIdx]
+				storeyAsset := &plannedAsset{kind: conf.StoreyAssetKind, identifier: storey.Uuid, parentIdentifier: &building.Uuid, assetType: eliona.GroupAssetType, name: storey.Name, building: building.Name, object: storey, parent: buildingAsset}
+				plan = append(plan, storeyAsset)
+
+				for spaceIdx := range storey.Children {
+					space := &storey.Children[spaceIdx]
+					assetType, ok := spaceAssetType(*space)
+					if !ok {
+						continue
 					}
-					if space.SpaceType == signify.HumiditySpaceType {
-						_, created, err := createAsset(config, projectId, space.Uuid, common.Ptr(storey.Uuid), &storeyAssetId, eliona.HumidityAssetType, conf.SpaceAssetKind, space.Name)
-						if err != nil {
-							return countCreated, fmt.Errorf("create space asset first time: %w", err)
-						}
-						if created {
-							countCreated++
-						}
+					if functionalGroup == nil {
+						functionalGroup = &plannedAsset{kind: conf.FunctionalGroupAssetKind, identifier: functionalGroupIdentifier, assetType: eliona.GroupAssetType, name: functionalGroupName}
+						plan = append(plan, functionalGroup)
 					}
-
+					plan = append(plan, &plannedAsset{kind: conf.SpaceAssetKind, identifier: space.Uuid, parentIdentifier: &storey.Uuid, assetType: assetType, name: space.Name, building: building.Name, object: space, parent: storeyAsset})
 				}
 			}
 		}
 	}
+	return plan
+}
 
-	return countCreated, nil
+// buildingsAttached returns true if all buildings of a site are attached to existing assets. No root and site assets
+// are needed for such a site, as the customer's own hierarchy already covers them.
+func buildingsAttached(site signify.Object, locationalParents map[string]int32) bool {
+	if len(site.Children) == 0 {
+		return false
+	}
+	for _, building := range site.Children {
+		if _, ok := locationalParents[building.Uuid]; !ok {
+			return false
+		}
+	}
+	return true
 }
 
-func notifyUser(userId string, projectId string, countCreated int) error {
-	receipt, _, err := client.NewClient().CommunicationAPI.
-		PostNotification(client.AuthenticationContext()).
-		Notification(
-			api.Notification{
-				User:      userId,
-				ProjectId: *api.NewNullableString(&projectId),
-				Message: *api.NewNullableTranslation(&api.Translation{
-					De: api.PtrString(fmt.Sprintf("Signify App hat %d neue Assets angelegt. Diese sind nun im Asset-Management verfügbar.", countCreated)),
-					En: api.PtrString(fmt.Sprintf("Signify app added %d new assets. They are now available in Asset Management.", countCreated)),
-				}),
-			}).
-		Execute()
-	log.Debug("eliona", "posted notification about CAC: %v", receipt)
+// spaceAssetType returns the asset type for a space. Spaces without supported space type are not created.
+func spaceAssetType(space signify.Object) (string, bool) {
+	switch space.SpaceType {
+	case signify.OccupancySpaceType:
+		return eliona.OccupancyAssetType, true
+	case signify.PeopleCountSpaceType:
+		return eliona.PeopleCountAssetType, true
+	case signify.TemperatureSpaceType:
+		return eliona.TemperatureAssetType, true
+	case signify.HumiditySpaceType:
+		return eliona.HumidityAssetType, true
+	}
+	return "", false
+}
+
+// functionalGroupIdentifier identifies the asset grouping all spaces functionally
+const functionalGroupIdentifier = "sensors"
+
+// functionalGroupName is the name of the asset grouping all spaces functionally
+const functionalGroupName = "Signify sensors"
+
+// assetMetadata returns the location and the tags of the asset for an object. The tags are the names of
+// the building and the storey and the function type of the object.
+func assetMetadata(object signify.Object, functionalParentId *int32) eliona.AssetMetadata {
+	var tags []string
+	for _, tag := range []string{object.BuildingName, object.StoreyName, object.FunctionType} {
+		if tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return eliona.AssetMetadata{
+		FunctionalParentId: functionalParentId,
+		Latitude:           object.Latitude,
+		Longitude:          object.Longitude,
+		Tags:               tags,
+	}
+}
+
+// globalAssetId returns the unique identifier of an asset, namespaced by the asset type
+func globalAssetId(assetType string, identifier string) string {
+	return assetType + "_" + identifier
+}
+
+// previewAssets collects the objects of a configuration and returns the asset tree createAssets would create
+// for each project of the configuration. Nothing is created.
+func previewAssets(config apiserver.Configuration) (apiserver.SyncPreview, error) {
+	var preview apiserver.SyncPreview
+
+	spaces, err := collectObjects(config, false)
 	if err != nil {
-		return fmt.Errorf("posting notification: %v", err)
+		return preview, fmt.Errorf("collecting spaces: %w", err)
 	}
-	return nil
+
+	mappings, err := conf.GetAssets(context.Background(),
+		appdb.AssetWhere.ConfigurationID.EQ(*config.Id),
+	)
+	if err != nil {
+		return preview, fmt.Errorf("getting asset mappings: %w", err)
+	}
+	existing := make(map[string]int32)
+	for _, mapping := range mappings {
+		existing[mapping.ProjectID+"/"+mapping.GlobalAssetID] = mapping.AssetID.Int32
+	}
+
+	// the plans of all bindings are merged into one tree with the mappings of each project
+	nodes := make(map[string]*apiserver.PreviewNode)
+	children := make(map[string][]string)
+	var topKeys []string
+	nodeKey := func(planned *plannedAsset) string {
+		return string(planned.kind) + "/" + planned.identifier
+	}
+	for _, binding := range conf.ProjectBindings(config) {
+		projectSpaces, err := signify.FilterObjects(spaces, binding.AssetFilter)
+		if err != nil {
+			return preview, fmt.Errorf("filtering spaces for project %s: %w", binding.ProjectId, err)
+		}
+		for _, planned := range planAssets(binding, projectSpaces) {
+			key := nodeKey(planned)
+			node, ok := nodes[key]
+			if !ok {
+				node = &apiserver.PreviewNode{
+					Kind:          string(planned.kind),
+					Name:          planned.name,
+					AssetType:     planned.assetType,
+					GlobalAssetId: globalAssetId(planned.assetType, planned.identifier),
+				}
+				if planned.object != nil {
+					node.Uuid = planned.object.Uuid
+				}
+				nodes[key] = node
+				if planned.parent != nil {
+					children[nodeKey(planned.parent)] = append(children[nodeKey(planned.parent)], key)
+				} else {
+					topKeys = append(topKeys, key)
+				}
+			}
+
+			mapping := apiserver.PreviewMapping{ProjectId: binding.ProjectId, State: "new"}
+			if planned.attachTo != nil {
+				mapping.State = "attached"
+				mapping.AssetId = planned.attachTo
+			} else if assetId, ok := existing[binding.ProjectId+"/"+node.GlobalAssetId]; ok {
+				mapping.State = "existing"
+				mapping.AssetId = common.Ptr(assetId)
+				preview.ExistingAssets++
+			} else {
+				preview.NewAssets++
+			}
+			node.Mappings = append(node.Mappings, mapping)
+		}
+	}
+
+	var buildNode func(key string) apiserver.PreviewNode
+	buildNode = func(key string) apiserver.PreviewNode {
+		node := *nodes[key]
+		for _, childKey := range children[key] {
+			node.Children = append(node.Children, buildNode(childKey))
+		}
+		return node
+	}
+	for _, key := range topKeys {
+		preview.Nodes = append(preview.Nodes, buildNode(key))
+	}
+
+	return preview, nil
 }
 
-func collectObjects(config apiserver.Configuration) ([]signify.Object, error) {
+// collectObjects reads the site hierarchy from Interact. The details of the spaces are only read if withDetails is set.
+func collectObjects(config apiserver.Configuration, withDetails bool) ([]signify.Object, error) {
+	logger := logging.Config("collect", *config.Id)
 
 	// Sites
 	sites, err := signify.GetSites(config)
@@ -263,7 +730,7 @@ func collectObjects(config apiserver.Configuration) ([]signify.Object, error) {
 		return nil, err
 	}
 	for siteIdx, site := range sites {
-		log.Debug("collect", "Site: %s", site.Name)
+		logger.Debug("Site", "name", site.Name)
 
 		// Buildings
 		buildings, err := signify.GetBuildings(config, site)
@@ -272,7 +739,8 @@ func collectObjects(config apiserver.Configuration) ([]signify.Object, error) {
 		}
 		sites[siteIdx].Children = buildings
 		for buildingIdx, building := range buildings {
-			log.Debug("collect", "Building: %s", building.Name)
+			buildingLogger := logger.With(logging.BuildingUUID(building.Uuid))
+			buildingLogger.Debug("Building", "name", building.Name)
 
 			// Storeys
 			storeys, err := signify.GetStoreys(config, building)
@@ -281,7 +749,7 @@ func collectObjects(config apiserver.Configuration) ([]signify.Object, error) {
 			}
 			sites[siteIdx].Children[buildingIdx].Children = storeys
 			for storeyIdx, storey := range storeys {
-				log.Debug("collect", "Storey: %s", storey.Name)
+				buildingLogger.Debug("Storey", "name", storey.Name)
 
 				// Spaces
 				spaces, err := signify.GetSensorSpaces(config, storey)
@@ -289,8 +757,19 @@ func collectObjects(config apiserver.Configuration) ([]signify.Object, error) {
 					return nil, err
 				}
 				sites[siteIdx].Children[buildingIdx].Children[storeyIdx].Children = spaces
-				for _, space := range spaces {
-					log.Debug("collect", "Space: %s", space.Name)
+				for spaceIdx, space := range spaces {
+					buildingLogger.Debug("Space", "name", space.Name)
+					if !withDetails {
+						continue
+					}
+
+					// missing details shouldn't prevent creating the space
+					details, err := signify.GetSpaceDetails(config, space)
+					if err != nil {
+						buildingLogger.Warn("Error getting details of space", "name", space.Name, "error", err)
+						continue
+					}
+					sites[siteIdx].Children[buildingIdx].Children[storeyIdx].Children[spaceIdx].Details = details
 				}
 			}
 		}
@@ -299,39 +778,285 @@ func collectObjects(config apiserver.Configuration) ([]signify.Object, error) {
 	return sites, nil
 }
 
-// createAsset creates an asset if not exists. otherwise the current asset id is returned.
-func createAsset(config apiserver.Configuration, projectId string, identifier string, parentIdentifier *string, parentId *int32, assetType string, kind conf.AssetKind, name string) (int32, bool, error) {
-	uniqueIdentifier := assetType + "_" + identifier
+// createAsset creates an asset if not exists. Otherwise, the asset is updated in Eliona if its name, parents, location
+// or tags changed, and the current asset id is returned.
+func createAsset(config apiserver.Configuration, projectId string, identifier string, parentIdentifier *string, parentId *int32, assetType string, kind conf.AssetKind, name string, metadata eliona.AssetMetadata) (int32, bool, error) {
+	uniqueIdentifier := globalAssetId(assetType, identifier)
 	ctx := context.Background()
+	logger := logging.Config("assets", *config.Id).With(logging.ProjectId(projectId), "global_asset_id", uniqueIdentifier)
 
 	// check if asset already exists in app
-	assetId, err := conf.GetAssetIdWithGAI(ctx, config, projectId, uniqueIdentifier)
+	mapping, err := conf.GetAssetWithGAI(ctx, config, projectId, uniqueIdentifier)
 	if err != nil {
 		return 0, false, fmt.Errorf("get asset id for %s in app: %w", uniqueIdentifier, err)
 	}
 
+	// an object no longer attached to an existing asset gets an own asset and its children are moved there
+	if mapping != nil && mapping.Attached {
+		logger.Info("Detaching from asset", "asset_id", mapping.AssetID.Int32)
+		if err := deleteAssetMappingTree(ctx, config, projectId, identifier); err != nil {
+			return 0, false, fmt.Errorf("delete mappings of detached %s in app: %w", uniqueIdentifier, err)
+		}
+		mapping = nil
+	}
+
+	definition, err := assetDefinition(parentId, name, metadata)
+	if err != nil {
+		return 0, false, fmt.Errorf("marshalling definition of %s: %w", uniqueIdentifier, err)
+	}
+
+	var assetId *int32
+	if mapping != nil {
+		assetId = common.Ptr(mapping.AssetID.Int32)
+
+		// the asset is only written again if its fields changed in Interact, so edits in Eliona are kept until then
+		if mapping.AssetType.String != assetType || mapping.Definition.String != definition {
+			if _, err := eliona.UpsertAsset(projectId, uniqueIdentifier, parentId, assetType, name, metadata); err != nil {
+				return 0, false, fmt.Errorf("updating asset %s in Eliona: %w", uniqueIdentifier, err)
+			}
+			if err := conf.SetAssetDefinition(ctx, mapping, assetType, name, definition); err != nil {
+				return 0, false, fmt.Errorf("set definition of %s in app: %w", uniqueIdentifier, err)
+			}
+			logger.Info("Asset updated", "asset_id", *assetId)
+		}
+	}
+
 	// if not, create asset in Eliona also
 	if assetId == nil {
 
-		log.Debug("assets", "No asset id found for %s", uniqueIdentifier)
-		assetId, err = eliona.UpsertAsset(projectId, uniqueIdentifier, parentId, assetType, name)
+		logger.Debug("No asset id found")
+		assetId, err = eliona.UpsertAsset(projectId, uniqueIdentifier, parentId, assetType, name, metadata)
 		if err != nil || assetId == nil {
 			return 0, false, fmt.Errorf("upserting root asset %s in Eliona: %w", uniqueIdentifier, err)
 		}
 
-		err = conf.InsertAsset(ctx, config, projectId, identifier, parentIdentifier, uniqueIdentifier, kind, *assetId)
+		err = conf.InsertAsset(ctx, config, projectId, identifier, parentIdentifier, uniqueIdentifier, kind, *assetId, assetType, name, definition)
 		if err != nil {
 			return 0, false, fmt.Errorf("insert asset %s in app: %w", uniqueIdentifier, err)
 		}
-		log.Debug("assets", "Asset created for %s with id %d", uniqueIdentifier, *assetId)
-
+		if remapped(*assetId) {
+			logger.Debug("Asset mapped again", "asset_id", *assetId)
+			return *assetId, false, nil
+		}
+		logger.Debug("Asset created", "asset_id", *assetId)
+		events.Record(*config.Id, events.AssetCreatedEventType, "Created %s asset %s with id %d in project %s", kind, uniqueIdentifier, *assetId, projectId)
 		return *assetId, true, nil
 	} else {
-		log.Debug("assets", "Asset already created for %s with id %d", uniqueIdentifier, *assetId)
+		logger.Debug("Asset already created", "asset_id", *assetId)
 		return *assetId, false, nil
 	}
 }
 
+// unmappedAssets holds the Eliona assets whose mappings were removed with a detached or re-attached ancestor. The
+// synchronisation maps them again, but as the assets already exist in Eliona, they are not counted as created.
+var unmappedAssets = make(map[int32]bool)
+var unmappedAssetsMutex sync.Mutex
+
+// deleteAssetMappingTree removes the mappings of an object and its descendants and remembers the assets of the
+// descendants, which are mapped again
+func deleteAssetMappingTree(ctx context.Context, config apiserver.Configuration, projectId string, identifier string) error {
+	removed, err := conf.DeleteAssetMappingTree(ctx, config, projectId, identifier)
+	if err != nil {
+		return err
+	}
+	unmappedAssetsMutex.Lock()
+	defer unmappedAssetsMutex.Unlock()
+	for _, mapping := range removed {
+		if !mapping.Attached && mapping.AssetID.Valid {
+			unmappedAssets[mapping.AssetID.Int32] = true
+		}
+	}
+	return nil
+}
+
+// remapped returns true if the asset was unmapped with an ancestor and is now mapped again
+func remapped(assetId int32) bool {
+	unmappedAssetsMutex.Lock()
+	defer unmappedAssetsMutex.Unlock()
+	if !unmappedAssets[assetId] {
+		return false
+	}
+	delete(unmappedAssets, assetId)
+	return true
+}
+
+// assetDefinition returns the fields written to Eliona for an asset, which are kept with the mapping to detect changes
+func assetDefinition(parentId *int32, name string, metadata eliona.AssetMetadata) (string, error) {
+	definition, err := json.Marshal(struct {
+		Name     string
+		ParentId *int32
+		eliona.AssetMetadata
+	}{name, parentId, metadata})
+	return string(definition), err
+}
+
+// provisionedAlarmTemplates holds the alarm templates of each configuration, which were provisioned for all its assets
+var provisionedAlarmTemplates = make(map[int64]string)
+var provisionedAlarmTemplatesMutex sync.Mutex
+
+// alarmTemplatesChanged returns the alarm templates of a configuration and whether they changed since they were last
+// provisioned for all assets. Existing assets only get their alarm rules provisioned again if they changed.
+func alarmTemplatesChanged(config apiserver.Configuration) (string, bool) {
+	templates, err := json.Marshal(conf.AlarmTemplates(config))
+	if err != nil {
+		return "", true
+	}
+	provisionedAlarmTemplatesMutex.Lock()
+	defer provisionedAlarmTemplatesMutex.Unlock()
+	provisioned, ok := provisionedAlarmTemplates[*config.Id]
+	return string(templates), !ok || provisioned != string(templates)
+}
+
+func setAlarmTemplatesProvisioned(config apiserver.Configuration, templates string) {
+	if templates == "" {
+		return
+	}
+	provisionedAlarmTemplatesMutex.Lock()
+	defer provisionedAlarmTemplatesMutex.Unlock()
+	provisionedAlarmTemplates[*config.Id] = templates
+}
+
+// provisionAlarmRules creates the alarm rules of the templates matching the asset type for an asset. Existing alarm
+// rules are only updated if their template changed.
+func provisionAlarmRules(config apiserver.Configuration, assetId int32, assetType string) {
+	templates := conf.AlarmTemplates(config)
+	if len(templates) == 0 {
+		return
+	}
+	ctx := context.Background()
+	logger := logging.Config("alarms", *config.Id).With("asset_id", assetId)
+	existing, err := conf.GetAlarmRules(ctx, *config.Id, appdb.AlarmRuleWhere.AssetID.EQ(assetId))
+	if err != nil {
+		logger.Error("Error getting alarm rules", "error", err)
+		return
+	}
+	existingByTemplate := make(map[string]*appdb.AlarmRule)
+	for _, alarmRule := range existing {
+		existingByTemplate[alarmRule.TemplateName] = alarmRule
+	}
+
+	for _, template := range templates {
+		if template.AssetType != assetType {
+			continue
+		}
+		rule := eliona.AlarmRule(template, assetId)
+		definition, err := json.Marshal(rule)
+		if err != nil {
+			logger.Error("Error marshalling alarm rule", "template", template.Name, "error", err)
+			continue
+		}
+		if alarmRule, ok := existingByTemplate[template.Name]; ok {
+			if alarmRule.Definition == string(definition) {
+				continue
+			}
+			rule.Id = *api.NewNullableInt32(common.Ptr(alarmRule.AlarmRuleID))
+		}
+		alarmRuleId, err := eliona.UpsertAlarmRule(rule)
+		if err != nil {
+			logger.Error("Error upserting alarm rule", "template", template.Name, "error", err)
+			continue
+		}
+		if err := conf.UpsertAlarmRule(ctx, *config.Id, assetId, template.Name, alarmRuleId, string(definition)); err != nil {
+			logger.Error("Error storing alarm rule", "template", template.Name, "error", err)
+			continue
+		}
+		logger.Debug("Alarm rule provisioned", "template", template.Name, "alarm_rule_id", alarmRuleId)
+	}
+}
+
+// removeObsoleteAlarmRules deletes the alarm rules whose template was removed from the configuration or whose
+// asset is no longer mapped
+func removeObsoleteAlarmRules(config apiserver.Configuration) error {
+	ctx := context.Background()
+	alarmRules, err := conf.GetAlarmRules(ctx, *config.Id)
+	if err != nil || len(alarmRules) == 0 {
+		return err
+	}
+	templateNames := make(map[string]bool)
+	for _, template := range conf.AlarmTemplates(config) {
+		templateNames[template.Name] = true
+	}
+	mappings, err := conf.GetAssets(ctx, appdb.AssetWhere.ConfigurationID.EQ(*config.Id))
+	if err != nil {
+		return fmt.Errorf("getting asset mappings: %w", err)
+	}
+	assetIds := make(map[int32]bool)
+	for _, mapping := range mappings {
+		if mapping.AssetID.Valid && !mapping.Attached {
+			assetIds[mapping.AssetID.Int32] = true
+		}
+	}
+
+	for _, alarmRule := range alarmRules {
+		if templateNames[alarmRule.TemplateName] && assetIds[alarmRule.AssetID] {
+			continue
+		}
+		if err := eliona.DeleteAlarmRule(alarmRule.AlarmRuleID); err != nil {
+			return fmt.Errorf("deleting alarm rule %d in Eliona: %w", alarmRule.AlarmRuleID, err)
+		}
+		if err := conf.DeleteAlarmRule(ctx, alarmRule); err != nil {
+			return fmt.Errorf("deleting alarm rule %d in app: %w", alarmRule.AlarmRuleID, err)
+		}
+		logging.Config("alarms", *config.Id).Info("Removed alarm rule", "template", alarmRule.TemplateName, "asset_id", alarmRule.AssetID)
+	}
+	return nil
+}
+
+// attachAsset maps an object to an existing Eliona asset instead of creating an own asset. If the object was mapped
+// to another asset before, the mappings of its descendants are removed, so they are moved below the attached asset.
+func attachAsset(config apiserver.Configuration, projectId string, identifier string, parentIdentifier *string, assetType string, kind conf.AssetKind, assetId int32) (int32, error) {
+	uniqueIdentifier := globalAssetId(assetType, identifier)
+	ctx := context.Background()
+	logger := logging.Config("assets", *config.Id).With(logging.ProjectId(projectId), "global_asset_id", uniqueIdentifier)
+
+	mapping, err := conf.GetAssetWithGAI(ctx, config, projectId, uniqueIdentifier)
+	if err != nil {
+		return 0, fmt.Errorf("get asset id for %s in app: %w", uniqueIdentifier, err)
+	}
+	if mapping != nil && mapping.Attached && mapping.AssetID.Int32 == assetId {
+		return assetId, nil
+	}
+
+	if mapping != nil {
+		if !mapping.Attached {
+			logger.Warn("Created asset is replaced by attached asset and can be deleted", "asset_id", mapping.AssetID.Int32, "attached_asset_id", assetId)
+		}
+		if err := deleteAssetMappingTree(ctx, config, projectId, identifier); err != nil {
+			return 0, fmt.Errorf("delete mappings of %s in app: %w", uniqueIdentifier, err)
+		}
+	}
+
+	if err := conf.InsertAttachedAsset(ctx, config, projectId, identifier, parentIdentifier, uniqueIdentifier, kind, assetId); err != nil {
+		return 0, fmt.Errorf("insert attached asset %s in app: %w", uniqueIdentifier, err)
+	}
+	logger.Info("Attached to existing asset", "asset_id", assetId)
+	return assetId, nil
+}
+
+// spaceDetails caches the details last written to each space asset
+var spaceDetails = make(map[int32]signify.SpaceDetails)
+var spaceDetailsMutex sync.Mutex
+
+// upsertSpaceDetails writes the details of a space as info attributes if they changed since the last write.
+// Returns true if details written before were changed.
+func upsertSpaceDetails(config apiserver.Configuration, assetId int32, space signify.Object) bool {
+	if space.Details == nil {
+		return false
+	}
+	spaceDetailsMutex.Lock()
+	defer spaceDetailsMutex.Unlock()
+	written, ok := spaceDetails[assetId]
+	if ok && reflect.DeepEqual(written, *space.Details) {
+		return false
+	}
+	if err := eliona.UpsertData(assetId, *space.Details); err != nil {
+		logging.Config("data", *config.Id).Error("Error upserting space details", "space", space.Name, "asset_id", assetId, "error", err)
+		return false
+	}
+	spaceDetails[assetId] = *space.Details
+	return ok
+}
+
 var subscriptionsMutex sync.Mutex
 
 // subscribeData subscribes for new data
@@ -342,67 +1067,115 @@ func subscribeData(config apiserver.Configuration) {
 	subscriptionsMutex.Lock()
 	defer subscriptionsMutex.Unlock()
 
-	log.Info("main", "Stopping all previous subscriptions for configuration id %d", *config.Id)
+	logger := logging.Config("listening", *config.Id)
+	if !leader.IsLeader(*config.Id) {
+		logger.Info("Not subscribing, the configuration is led by another instance")
+		return
+	}
+	logger.Info("Stopping all previous subscriptions")
 
 	signify.CloseExistingSubscriptions(config)
 
-	log.Info("main", "Start subscribing new data for configuration id %d", *config.Id)
+	logger.Info("Start subscribing new data")
 
 	buildings, err := conf.GetAssets(context.Background(),
 		appdb.AssetWhere.ConfigurationID.EQ(null.Int64FromPtr(config.Id).Int64),
 		appdb.AssetWhere.Kind.EQ(string(conf.BuildingAssetKind)),
 	)
 	if err != nil {
-		log.Fatal("listening", "Error collect buildings: %v", err)
+		logging.Fatal(logger, "Error collect buildings", "error", err)
 		return
 	}
 
+	// the same building can be mapped in several projects, but is subscribed only once
+	var buildingUUIDs []string
+	for _, building := range buildings {
+		if !slices.Contains(buildingUUIDs, building.UUID) {
+			buildingUUIDs = append(buildingUUIDs, building.UUID)
+		}
+	}
+
+	var subscribed []string
 	for _, subscriptionType := range []signify.SubscriptionType{signify.OccupancySubscriptionType, signify.HumiditySubscriptionType, signify.TemperatureSubscriptionType, signify.PeopleCountSubscriptionType} {
-		for _, building := range buildings {
-			url, err := signify.GetSubscriptionUrl(config, building.UUID, subscriptionType)
+		for _, buildingUUID := range buildingUUIDs {
+			subject := notification.SubscriptionSubject(string(subscriptionType), buildingUUID)
+			url, err := signify.GetSubscriptionUrl(config, buildingUUID, subscriptionType)
 			if err != nil {
-				log.Error("listening", "Error getting websocket URL: %v", err)
+				notification.Failed(*config.Id, notification.SubscriptionFailure, subject, err)
+				logger.Error("Error getting websocket URL", logging.BuildingUUID(buildingUUID), logging.SubscriptionType(string(subscriptionType)), "error", err)
 				continue
 			}
-			signify.Subscribe(config, *url, func(message signify.Message) {
-				upsertData(message, config)
+			subscribed = append(subscribed, subject)
+			signify.Subscribe(config, buildingUUID, subscriptionType, *url, func(message signify.Message) {
+				upsertData(message, config, subscriptionType)
 			})
 		}
 	}
+	// the subscriptions closed above and not subscribed again are stopped
+	for _, subject := range events.Running(*config.Id) {
+		if !slices.Contains(subscribed, subject) {
+			events.RecordStopped(*config.Id, subject, events.SubscriptionStoppedEventType, "Subscription %s stopped", subject)
+		}
+	}
 
-	log.Info("main", "Finished subscribing new data for configuration id %d successfully", *config.Id)
+	logger.Info("Finished subscribing new data successfully")
 
 }
 
+// checkStaleSpaces marks the spaces of all enabled configurations as stale, which stopped reporting
+func checkStaleSpaces() {
+	configs, err := conf.GetConfigs(context.Background())
+	if err != nil {
+		logging.Tag("conf").Error("Couldn't read configs from DB", "error", err)
+		return
+	}
+	for _, config := range configs {
+		if !conf.IsConfigEnabled(config) || !leader.IsLeader(*config.Id) {
+			continue
+		}
+		analytics.CheckStale(*config.Id, conf.StaleThresholds(config), conf.IsStaleOccupancyUnknown(config))
+	}
+}
+
 // upsertData upsert data
-func upsertData(message signify.Message, config apiserver.Configuration) {
+func upsertData(message signify.Message, config apiserver.Configuration, subscriptionType signify.SubscriptionType) {
+	logger := logging.Config("data", *config.Id).With(logging.SubscriptionType(string(subscriptionType)), "space_uuid", message.SpaceId)
 	spaces, err := conf.GetAssets(context.Background(),
 		appdb.AssetWhere.ConfigurationID.EQ(null.Int64FromPtr(config.Id).Int64),
 		appdb.AssetWhere.UUID.EQ(message.SpaceId),
 	)
 	if err != nil {
-		log.Fatal("data", "Error getting assets with UUID %s: %v", message.SpaceId, err)
+		logging.Fatal(logger, "Error getting assets of space", "error", err)
 	}
 	for _, space := range spaces {
 		err := eliona.UpsertData(space.AssetID.Int32, message)
 		if err != nil {
-			log.Error("data", "Error upsert data %v: %v", message, err)
+			logger.Error("Error upsert data", logging.ProjectId(space.ProjectID), "asset_id", space.AssetID.Int32, "message", message, "error", err)
+			events.RecordFailure(*config.Id, events.DataWriteFailedEventType, "Writing %s data of asset %d failed: %v", subscriptionType, space.AssetID.Int32, err)
+		} else {
+			events.RecordSuccess(*config.Id, events.DataWriteFailedEventType)
 		}
+		analytics.Observe(space.AssetID.Int32, message)
+		analytics.Seen(*config.Id, space.AssetID.Int32, subscriptionType)
 	}
 }
 
 // listenApi starts the API server and listen for requests
 func listenApi() {
+	router := apiserver.NewRouter(
+		apiserver.NewConfigurationAPIController(apiservices.NewConfigurationApiService()),
+		apiserver.NewVersionAPIController(apiservices.NewVersionApiService()),
+		apiserver.NewCustomizationAPIController(apiservices.NewCustomizationApiService()),
+		apiserver.NewHealthAPIController(apiservices.NewHealthApiService()),
+		apiserver.NewAssetMappingAPIController(apiservices.NewAssetMappingApiService()),
+		apiserver.NewSynchronizationAPIController(apiservices.NewSynchronizationApiService(previewAssets)),
+		apiserver.NewEventLogAPIController(apiservices.NewEventLogApiService()),
+	)
+	router.Handle("/metrics", metrics.Handler())
 	err := http.ListenAndServe(":"+common.Getenv("API_SERVER_PORT", "3000"),
 		frontend.NewEnvironmentHandler(
-			utilshttp.NewCORSEnabledHandler(
-				apiserver.NewRouter(
-					apiserver.NewConfigurationAPIController(apiservices.NewConfigurationApiService()),
-					apiserver.NewVersionAPIController(apiservices.NewVersionApiService()),
-					apiserver.NewCustomizationAPIController(apiservices.NewCustomizationApiService()),
-				),
-			),
+			utilshttp.NewCORSEnabledHandler(router),
 		),
 	)
-	log.Fatal("main", "API server: %v", err)
+	logging.Fatal(logging.Tag("main"), "API server stopped", "error", err)
 }
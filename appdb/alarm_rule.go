@@ -0,0 +1,1316 @@
+// Code generated by SQLBoiler 4.16.1 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package appdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// AlarmRule is an object representing the database table.
+type AlarmRule struct {
+	ID              int64  `boil:"id" json:"id" toml:"id" yaml:"id"`
+	ConfigurationID int64  `boil:"configuration_id" json:"configuration_id" toml:"configuration_id" yaml:"configuration_id"`
+	AssetID         int32  `boil:"asset_id" json:"asset_id" toml:"asset_id" yaml:"asset_id"`
+	TemplateName    string `boil:"template_name" json:"template_name" toml:"template_name" yaml:"template_name"`
+	AlarmRuleID     int32  `boil:"alarm_rule_id" json:"alarm_rule_id" toml:"alarm_rule_id" yaml:"alarm_rule_id"`
+	Definition      string `boil:"definition" json:"definition" toml:"definition" yaml:"definition"`
+
+	R *alarmRuleR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L alarmRuleL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var AlarmRuleColumns = struct {
+	ID              string
+	ConfigurationID string
+	AssetID         string
+	TemplateName    string
+	AlarmRuleID     string
+	Definition      string
+}{
+	ID:              "id",
+	ConfigurationID: "configuration_id",
+	AssetID:         "asset_id",
+	TemplateName:    "template_name",
+	AlarmRuleID:     "alarm_rule_id",
+	Definition:      "definition",
+}
+
+var AlarmRuleTableColumns = struct {
+	ID              string
+	ConfigurationID string
+	AssetID         string
+	TemplateName    string
+	AlarmRuleID     string
+	Definition      string
+}{
+	ID:              "alarm_rule.id",
+	ConfigurationID: "alarm_rule.configuration_id",
+	AssetID:         "alarm_rule.asset_id",
+	TemplateName:    "alarm_rule.template_name",
+	AlarmRuleID:     "alarm_rule.alarm_rule_id",
+	Definition:      "alarm_rule.definition",
+}
+
+// Generated where
+
+type whereHelperint64 struct{ field string }
+
+func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint64) IN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+type whereHelperint32 struct{ field string }
+
+func (w whereHelperint32) EQ(x int32) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint32) NEQ(x int32) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint32) LT(x int32) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint32) LTE(x int32) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint32) GT(x int32) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint32) GTE(x int32) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint32) IN(slice []int32) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperint32) NIN(slice []int32) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+type whereHelperstring struct{ field string }
+
+func (w whereHelperstring) EQ(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperstring) NEQ(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperstring) LT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperstring) LTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperstring) GT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperstring) GTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperstring) LIKE(x string) qm.QueryMod   { return qm.Where(w.field+" LIKE ?", x) }
+func (w whereHelperstring) NLIKE(x string) qm.QueryMod  { return qm.Where(w.field+" NOT LIKE ?", x) }
+func (w whereHelperstring) ILIKE(x string) qm.QueryMod  { return qm.Where(w.field+" ILIKE ?", x) }
+func (w whereHelperstring) NILIKE(x string) qm.QueryMod { return qm.Where(w.field+" NOT ILIKE ?", x) }
+func (w whereHelperstring) IN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+var AlarmRuleWhere = struct {
+	ID              whereHelperint64
+	ConfigurationID whereHelperint64
+	AssetID         whereHelperint32
+	TemplateName    whereHelperstring
+	AlarmRuleID     whereHelperint32
+	Definition      whereHelperstring
+}{
+	ID:              whereHelperint64{field: "\"signify\".\"alarm_rule\".\"id\""},
+	ConfigurationID: whereHelperint64{field: "\"signify\".\"alarm_rule\".\"configuration_id\""},
+	AssetID:         whereHelperint32{field: "\"signify\".\"alarm_rule\".\"asset_id\""},
+	TemplateName:    whereHelperstring{field: "\"signify\".\"alarm_rule\".\"template_name\""},
+	AlarmRuleID:     whereHelperint32{field: "\"signify\".\"alarm_rule\".\"alarm_rule_id\""},
+	Definition:      whereHelperstring{field: "\"signify\".\"alarm_rule\".\"definition\""},
+}
+
+// AlarmRuleRels is where relationship names are stored.
+var AlarmRuleRels = struct {
+	Configuration string
+}{
+	Configuration: "Configuration",
+}
+
+// alarmRuleR is where relationships are stored.
+type alarmRuleR struct {
+	Configuration *Configuration `boil:"Configuration" json:"Configuration" toml:"Configuration" yaml:"Configuration"`
+}
+
+// NewStruct creates a new relationship struct
+func (*alarmRuleR) NewStruct() *alarmRuleR {
+	return &alarmRuleR{}
+}
+
+func (r *alarmRuleR) GetConfiguration() *Configuration {
+	if r == nil {
+		return nil
+	}
+	return r.Configuration
+}
+
+// alarmRuleL is where Load methods for each relationship are stored.
+type alarmRuleL struct{}
+
+var (
+	alarmRuleAllColumns            = []string{"id", "configuration_id", "asset_id", "template_name", "alarm_rule_id", "definition"}
+	alarmRuleColumnsWithoutDefault = []string{"configuration_id", "asset_id", "template_name", "alarm_rule_id", "definition"}
+	alarmRuleColumnsWithDefault    = []string{"id"}
+	alarmRulePrimaryKeyColumns     = []string{"id"}
+	alarmRuleGeneratedColumns      = []string{}
+)
+
+type (
+	// AlarmRuleSlice is an alias for a slice of pointers to AlarmRule.
+	// This should almost always be used instead of []AlarmRule.
+	AlarmRuleSlice []*AlarmRule
+	// AlarmRuleHook is the signature for custom AlarmRule hook methods
+	AlarmRuleHook func(context.Context, boil.ContextExecutor, *AlarmRule) error
+
+	alarmRuleQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	alarmRuleType                 = reflect.TypeOf(&AlarmRule{})
+	alarmRuleMapping              = queries.MakeStructMapping(alarmRuleType)
+	alarmRulePrimaryKeyMapping, _ = queries.BindMapping(alarmRuleType, alarmRuleMapping, alarmRulePrimaryKeyColumns)
+	alarmRuleInsertCacheMut       sync.RWMutex
+	alarmRuleInsertCache          = make(map[string]insertCache)
+	alarmRuleUpdateCacheMut       sync.RWMutex
+	alarmRuleUpdateCache          = make(map[string]updateCache)
+	alarmRuleUpsertCacheMut       sync.RWMutex
+	alarmRuleUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var alarmRuleAfterSelectMu sync.Mutex
+var alarmRuleAfterSelectHooks []AlarmRuleHook
+
+var alarmRuleBeforeInsertMu sync.Mutex
+var alarmRuleBeforeInsertHooks []AlarmRuleHook
+var alarmRuleAfterInsertMu sync.Mutex
+var alarmRuleAfterInsertHooks []AlarmRuleHook
+
+var alarmRuleBeforeUpdateMu sync.Mutex
+var alarmRuleBeforeUpdateHooks []AlarmRuleHook
+var alarmRuleAfterUpdateMu sync.Mutex
+var alarmRuleAfterUpdateHooks []AlarmRuleHook
+
+var alarmRuleBeforeDeleteMu sync.Mutex
+var alarmRuleBeforeDeleteHooks []AlarmRuleHook
+var alarmRuleAfterDeleteMu sync.Mutex
+var alarmRuleAfterDeleteHooks []AlarmRuleHook
+
+var alarmRuleBeforeUpsertMu sync.Mutex
+var alarmRuleBeforeUpsertHooks []AlarmRuleHook
+var alarmRuleAfterUpsertMu sync.Mutex
+var alarmRuleAfterUpsertHooks []AlarmRuleHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *AlarmRule) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range alarmRuleAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *AlarmRule) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range alarmRuleBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *AlarmRule) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range alarmRuleAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *AlarmRule) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range alarmRuleBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *AlarmRule) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range alarmRuleAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *AlarmRule) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range alarmRuleBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *AlarmRule) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range alarmRuleAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *AlarmRule) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range alarmRuleBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *AlarmRule) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range alarmRuleAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddAlarmRuleHook registers your hook function for all future operations.
+func AddAlarmRuleHook(hookPoint boil.HookPoint, alarmRuleHook AlarmRuleHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		alarmRuleAfterSelectMu.Lock()
+		alarmRuleAfterSelectHooks = append(alarmRuleAfterSelectHooks, alarmRuleHook)
+		alarmRuleAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		alarmRuleBeforeInsertMu.Lock()
+		alarmRuleBeforeInsertHooks = append(alarmRuleBeforeInsertHooks, alarmRuleHook)
+		alarmRuleBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		alarmRuleAfterInsertMu.Lock()
+		alarmRuleAfterInsertHooks = append(alarmRuleAfterInsertHooks, alarmRuleHook)
+		alarmRuleAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		alarmRuleBeforeUpdateMu.Lock()
+		alarmRuleBeforeUpdateHooks = append(alarmRuleBeforeUpdateHooks, alarmRuleHook)
+		alarmRuleBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		alarmRuleAfterUpdateMu.Lock()
+		alarmRuleAfterUpdateHooks = append(alarmRuleAfterUpdateHooks, alarmRuleHook)
+		alarmRuleAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		alarmRuleBeforeDeleteMu.Lock()
+		alarmRuleBeforeDeleteHooks = append(alarmRuleBeforeDeleteHooks, alarmRuleHook)
+		alarmRuleBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		alarmRuleAfterDeleteMu.Lock()
+		alarmRuleAfterDeleteHooks = append(alarmRuleAfterDeleteHooks, alarmRuleHook)
+		alarmRuleAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		alarmRuleBeforeUpsertMu.Lock()
+		alarmRuleBeforeUpsertHooks = append(alarmRuleBeforeUpsertHooks, alarmRuleHook)
+		alarmRuleBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		alarmRuleAfterUpsertMu.Lock()
+		alarmRuleAfterUpsertHooks = append(alarmRuleAfterUpsertHooks, alarmRuleHook)
+		alarmRuleAfterUpsertMu.Unlock()
+	}
+}
+
+// OneG returns a single alarmRule record from the query using the global executor.
+func (q alarmRuleQuery) OneG(ctx context.Context) (*AlarmRule, error) {
+	return q.One(ctx, boil.GetContextDB())
+}
+
+// One returns a single alarmRule record from the query.
+func (q alarmRuleQuery) One(ctx context.Context, exec boil.ContextExecutor) (*AlarmRule, error) {
+	o := &AlarmRule{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: failed to execute a one query for alarm_rule")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// AllG returns all AlarmRule records from the query using the global executor.
+func (q alarmRuleQuery) AllG(ctx context.Context) (AlarmRuleSlice, error) {
+	return q.All(ctx, boil.GetContextDB())
+}
+
+// All returns all AlarmRule records from the query.
+func (q alarmRuleQuery) All(ctx context.Context, exec boil.ContextExecutor) (AlarmRuleSlice, error) {
+	var o []*AlarmRule
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "appdb: failed to assign all query results to AlarmRule slice")
+	}
+
+	if len(alarmRuleAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// CountG returns the count of all AlarmRule records in the query using the global executor
+func (q alarmRuleQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
+}
+
+// Count returns the count of all AlarmRule records in the query.
+func (q alarmRuleQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to count alarm_rule rows")
+	}
+
+	return count, nil
+}
+
+// ExistsG checks if the row exists in the table using the global executor.
+func (q alarmRuleQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
+}
+
+// Exists checks if the row exists in the table.
+func (q alarmRuleQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: failed to check if alarm_rule exists")
+	}
+
+	return count > 0, nil
+}
+
+// Configuration pointed to by the foreign key.
+func (o *AlarmRule) Configuration(mods ...qm.QueryMod) configurationQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ConfigurationID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Configurations(queryMods...)
+}
+
+// LoadConfiguration allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (alarmRuleL) LoadConfiguration(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAlarmRule interface{}, mods queries.Applicator) error {
+	var slice []*AlarmRule
+	var object *AlarmRule
+
+	if singular {
+		var ok bool
+		object, ok = maybeAlarmRule.(*AlarmRule)
+		if !ok {
+			object = new(AlarmRule)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeAlarmRule)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeAlarmRule))
+			}
+		}
+	} else {
+		s, ok := maybeAlarmRule.(*[]*AlarmRule)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeAlarmRule)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeAlarmRule))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &alarmRuleR{}
+		}
+		args[object.ConfigurationID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &alarmRuleR{}
+			}
+
+			args[obj.ConfigurationID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`signify.configuration`),
+		qm.WhereIn(`signify.configuration.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Configuration")
+	}
+
+	var resultSlice []*Configuration
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Configuration")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for configuration")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for configuration")
+	}
+
+	if len(configurationAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Configuration = foreign
+		if foreign.R == nil {
+			foreign.R = &configurationR{}
+		}
+		foreign.R.AlarmRules = append(foreign.R.AlarmRules, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ConfigurationID == foreign.ID {
+				local.R.Configuration = foreign
+				if foreign.R == nil {
+					foreign.R = &configurationR{}
+				}
+				foreign.R.AlarmRules = append(foreign.R.AlarmRules, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetConfigurationG of the alarmRule to the related item.
+// Sets o.R.Configuration to related.
+// Adds o to related.R.AlarmRules.
+// Uses the global database handle.
+func (o *AlarmRule) SetConfigurationG(ctx context.Context, insert bool, related *Configuration) error {
+	return o.SetConfiguration(ctx, boil.GetContextDB(), insert, related)
+}
+
+// SetConfiguration of the alarmRule to the related item.
+// Sets o.R.Configuration to related.
+// Adds o to related.R.AlarmRules.
+func (o *AlarmRule) SetConfiguration(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Configuration) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"signify\".\"alarm_rule\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"configuration_id"}),
+		strmangle.WhereClause("\"", "\"", 2, alarmRulePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ConfigurationID = related.ID
+	if o.R == nil {
+		o.R = &alarmRuleR{
+			Configuration: related,
+		}
+	} else {
+		o.R.Configuration = related
+	}
+
+	if related.R == nil {
+		related.R = &configurationR{
+			AlarmRules: AlarmRuleSlice{o},
+		}
+	} else {
+		related.R.AlarmRules = append(related.R.AlarmRules, o)
+	}
+
+	return nil
+}
+
+// AlarmRules retrieves all the records using an executor.
+func AlarmRules(mods ...qm.QueryMod) alarmRuleQuery {
+	mods = append(mods, qm.From("\"signify\".\"alarm_rule\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"signify\".\"alarm_rule\".*"})
+	}
+
+	return alarmRuleQuery{q}
+}
+
+// FindAlarmRuleG retrieves a single record by ID.
+func FindAlarmRuleG(ctx context.Context, iD int64, selectCols ...string) (*AlarmRule, error) {
+	return FindAlarmRule(ctx, boil.GetContextDB(), iD, selectCols...)
+}
+
+// FindAlarmRule retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindAlarmRule(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*AlarmRule, error) {
+	alarmRuleObj := &AlarmRule{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"signify\".\"alarm_rule\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, alarmRuleObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "appdb: unable to select from alarm_rule")
+	}
+
+	if err = alarmRuleObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return alarmRuleObj, err
+	}
+
+	return alarmRuleObj, nil
+}
+
+// InsertG a single record. See Insert for whitelist behavior description.
+func (o *AlarmRule) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *AlarmRule) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("appdb: no alarm_rule provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(alarmRuleColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	alarmRuleInsertCacheMut.RLock()
+	cache, cached := alarmRuleInsertCache[key]
+	alarmRuleInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			alarmRuleAllColumns,
+			alarmRuleColumnsWithDefault,
+			alarmRuleColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(alarmRuleType, alarmRuleMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(alarmRuleType, alarmRuleMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"signify\".\"alarm_rule\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"signify\".\"alarm_rule\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to insert into alarm_rule")
+	}
+
+	if !cached {
+		alarmRuleInsertCacheMut.Lock()
+		alarmRuleInsertCache[key] = cache
+		alarmRuleInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// UpdateG a single AlarmRule record using the global executor.
+// See Update for more documentation.
+func (o *AlarmRule) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
+}
+
+// Update uses an executor to update the AlarmRule.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *AlarmRule) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	alarmRuleUpdateCacheMut.RLock()
+	cache, cached := alarmRuleUpdateCache[key]
+	alarmRuleUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			alarmRuleAllColumns,
+			alarmRulePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("appdb: unable to update alarm_rule, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"signify\".\"alarm_rule\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, alarmRulePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(alarmRuleType, alarmRuleMapping, append(wl, alarmRulePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update alarm_rule row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by update for alarm_rule")
+	}
+
+	if !cached {
+		alarmRuleUpdateCacheMut.Lock()
+		alarmRuleUpdateCache[key] = cache
+		alarmRuleUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (q alarmRuleQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q alarmRuleQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all for alarm_rule")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected for alarm_rule")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (o AlarmRuleSlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o AlarmRuleSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("appdb: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), alarmRulePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"signify\".\"alarm_rule\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, alarmRulePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to update all in alarmRule slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to retrieve rows affected all in update all alarmRule")
+	}
+	return rowsAff, nil
+}
+
+// UpsertG attempts an insert, and does an update or ignore on conflict.
+func (o *AlarmRule) UpsertG(ctx context.Context, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	return o.Upsert(ctx, boil.GetContextDB(), updateOnConflict, conflictColumns, updateColumns, insertColumns, opts...)
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *AlarmRule) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("appdb: no alarm_rule provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(alarmRuleColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	alarmRuleUpsertCacheMut.RLock()
+	cache, cached := alarmRuleUpsertCache[key]
+	alarmRuleUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			alarmRuleAllColumns,
+			alarmRuleColumnsWithDefault,
+			alarmRuleColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			alarmRuleAllColumns,
+			alarmRulePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("appdb: unable to upsert alarm_rule, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(alarmRuleAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(alarmRulePrimaryKeyColumns) == 0 {
+				return errors.New("appdb: unable to upsert alarm_rule, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(alarmRulePrimaryKeyColumns))
+			copy(conflict, alarmRulePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"signify\".\"alarm_rule\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(alarmRuleType, alarmRuleMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(alarmRuleType, alarmRuleMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to upsert alarm_rule")
+	}
+
+	if !cached {
+		alarmRuleUpsertCacheMut.Lock()
+		alarmRuleUpsertCache[key] = cache
+		alarmRuleUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// DeleteG deletes a single AlarmRule record.
+// DeleteG will match against the primary key column to find the record to delete.
+func (o *AlarmRule) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
+}
+
+// Delete deletes a single AlarmRule record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *AlarmRule) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("appdb: no AlarmRule provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), alarmRulePrimaryKeyMapping)
+	sql := "DELETE FROM \"signify\".\"alarm_rule\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete from alarm_rule")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by delete for alarm_rule")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+func (q alarmRuleQuery) DeleteAllG(ctx context.Context) (int64, error) {
+	return q.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all matching rows.
+func (q alarmRuleQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("appdb: no alarmRuleQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from alarm_rule")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for alarm_rule")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAllG deletes all rows in the slice.
+func (o AlarmRuleSlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o AlarmRuleSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(alarmRuleBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), alarmRulePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"signify\".\"alarm_rule\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, alarmRulePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: unable to delete all from alarmRule slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "appdb: failed to get rows affected by deleteall for alarm_rule")
+	}
+
+	if len(alarmRuleAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// ReloadG refetches the object from the database using the primary keys.
+func (o *AlarmRule) ReloadG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: no AlarmRule provided for reload")
+	}
+
+	return o.Reload(ctx, boil.GetContextDB())
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *AlarmRule) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindAlarmRule(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAllG refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *AlarmRuleSlice) ReloadAllG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("appdb: empty AlarmRuleSlice provided for reload all")
+	}
+
+	return o.ReloadAll(ctx, boil.GetContextDB())
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *AlarmRuleSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := AlarmRuleSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), alarmRulePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"signify\".\"alarm_rule\".* FROM \"signify\".\"alarm_rule\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, alarmRulePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "appdb: unable to reload all in AlarmRuleSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// AlarmRuleExistsG checks if the AlarmRule row exists.
+func AlarmRuleExistsG(ctx context.Context, iD int64) (bool, error) {
+	return AlarmRuleExists(ctx, boil.GetContextDB(), iD)
+}
+
+// AlarmRuleExists checks if the AlarmRule row exists.
+func AlarmRuleExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"signify\".\"alarm_rule\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "appdb: unable to check if alarm_rule exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the AlarmRule row exists.
+func (o *AlarmRule) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return AlarmRuleExists(ctx, exec, o.ID)
+}
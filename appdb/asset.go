@@ -32,6 +32,10 @@ type Asset struct {
 	ProjectID       string      `boil:"project_id" json:"project_id" toml:"project_id" yaml:"project_id"`
 	GlobalAssetID   string      `boil:"global_asset_id" json:"global_asset_id" toml:"global_asset_id" yaml:"global_asset_id"`
 	AssetID         null.Int32  `boil:"asset_id" json:"asset_id,omitempty" toml:"asset_id" yaml:"asset_id,omitempty"`
+	Attached        bool        `boil:"attached" json:"attached" toml:"attached" yaml:"attached"`
+	AssetType       null.String `boil:"asset_type" json:"asset_type,omitempty" toml:"asset_type" yaml:"asset_type,omitempty"`
+	Name            null.String `boil:"name" json:"name,omitempty" toml:"name" yaml:"name,omitempty"`
+	Definition      null.String `boil:"definition" json:"definition,omitempty" toml:"definition" yaml:"definition,omitempty"`
 
 	R *assetR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L assetL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -46,6 +50,10 @@ var AssetColumns = struct {
 	ProjectID       string
 	GlobalAssetID   string
 	AssetID         string
+	Attached        string
+	AssetType       string
+	Name            string
+	Definition      string
 }{
 	ID:              "id",
 	Kind:            "kind",
@@ -55,6 +63,10 @@ var AssetColumns = struct {
 	ProjectID:       "project_id",
 	GlobalAssetID:   "global_asset_id",
 	AssetID:         "asset_id",
+	Attached:        "attached",
+	AssetType:       "asset_type",
+	Name:            "name",
+	Definition:      "definition",
 }
 
 var AssetTableColumns = struct {
@@ -66,6 +78,10 @@ var AssetTableColumns = struct {
 	ProjectID       string
 	GlobalAssetID   string
 	AssetID         string
+	Attached        string
+	AssetType       string
+	Name            string
+	Definition      string
 }{
 	ID:              "asset.id",
 	Kind:            "asset.kind",
@@ -75,60 +91,14 @@ var AssetTableColumns = struct {
 	ProjectID:       "asset.project_id",
 	GlobalAssetID:   "asset.global_asset_id",
 	AssetID:         "asset.asset_id",
+	Attached:        "asset.attached",
+	AssetType:       "asset.asset_type",
+	Name:            "asset.name",
+	Definition:      "asset.definition",
 }
 
 // Generated where
 
-type whereHelperint64 struct{ field string }
-
-func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint64) IN(slice []int64) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-type whereHelperstring struct{ field string }
-
-func (w whereHelperstring) EQ(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) LIKE(x string) qm.QueryMod   { return qm.Where(w.field+" LIKE ?", x) }
-func (w whereHelperstring) NLIKE(x string) qm.QueryMod  { return qm.Where(w.field+" NOT LIKE ?", x) }
-func (w whereHelperstring) ILIKE(x string) qm.QueryMod  { return qm.Where(w.field+" ILIKE ?", x) }
-func (w whereHelperstring) NILIKE(x string) qm.QueryMod { return qm.Where(w.field+" NOT ILIKE ?", x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
 type whereHelpernull_String struct{ field string }
 
 func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
@@ -217,6 +187,15 @@ func (w whereHelpernull_Int32) NIN(slice []int32) qm.QueryMod {
 func (w whereHelpernull_Int32) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
 func (w whereHelpernull_Int32) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
+type whereHelperbool struct{ field string }
+
+func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperbool) NEQ(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperbool) LT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
 var AssetWhere = struct {
 	ID              whereHelperint64
 	Kind            whereHelperstring
@@ -226,6 +205,10 @@ var AssetWhere = struct {
 	ProjectID       whereHelperstring
 	GlobalAssetID   whereHelperstring
 	AssetID         whereHelpernull_Int32
+	Attached        whereHelperbool
+	AssetType       whereHelpernull_String
+	Name            whereHelpernull_String
+	Definition      whereHelpernull_String
 }{
 	ID:              whereHelperint64{field: "\"signify\".\"asset\".\"id\""},
 	Kind:            whereHelperstring{field: "\"signify\".\"asset\".\"kind\""},
@@ -235,6 +218,10 @@ var AssetWhere = struct {
 	ProjectID:       whereHelperstring{field: "\"signify\".\"asset\".\"project_id\""},
 	GlobalAssetID:   whereHelperstring{field: "\"signify\".\"asset\".\"global_asset_id\""},
 	AssetID:         whereHelpernull_Int32{field: "\"signify\".\"asset\".\"asset_id\""},
+	Attached:        whereHelperbool{field: "\"signify\".\"asset\".\"attached\""},
+	AssetType:       whereHelpernull_String{field: "\"signify\".\"asset\".\"asset_type\""},
+	Name:            whereHelpernull_String{field: "\"signify\".\"asset\".\"name\""},
+	Definition:      whereHelpernull_String{field: "\"signify\".\"asset\".\"definition\""},
 }
 
 // AssetRels is where relationship names are stored.
@@ -265,9 +252,9 @@ func (r *assetR) GetConfiguration() *Configuration {
 type assetL struct{}
 
 var (
-	assetAllColumns            = []string{"id", "kind", "uuid", "parent_uuid", "configuration_id", "project_id", "global_asset_id", "asset_id"}
+	assetAllColumns            = []string{"id", "kind", "uuid", "parent_uuid", "configuration_id", "project_id", "global_asset_id", "asset_id", "attached", "asset_type", "name", "definition"}
 	assetColumnsWithoutDefault = []string{"kind", "uuid", "project_id", "global_asset_id"}
-	assetColumnsWithDefault    = []string{"id", "parent_uuid", "configuration_id", "asset_id"}
+	assetColumnsWithDefault    = []string{"id", "parent_uuid", "configuration_id", "asset_id", "attached", "asset_type", "name", "definition"}
 	assetPrimaryKeyColumns     = []string{"id"}
 	assetGeneratedColumns      = []string{}
 )
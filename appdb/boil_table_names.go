@@ -4,9 +4,15 @@
 package appdb
 
 var TableNames = struct {
+	AlarmRule     string
 	Asset         string
 	Configuration string
+	Event         string
+	SyncJob       string
 }{
+	AlarmRule:     "alarm_rule",
 	Asset:         "asset",
 	Configuration: "configuration",
+	Event:         "event",
+	SyncJob:       "sync_job",
 }
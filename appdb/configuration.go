@@ -25,114 +25,126 @@ import (
 
 // Configuration is an object representing the database table.
 type Configuration struct {
-	ID              int64             `boil:"id" json:"id" toml:"id" yaml:"id"`
-	BaseURL         string            `boil:"base_url" json:"base_url" toml:"base_url" yaml:"base_url"`
-	Service         string            `boil:"service" json:"service" toml:"service" yaml:"service"`
-	ServiceID       string            `boil:"service_id" json:"service_id" toml:"service_id" yaml:"service_id"`
-	ServiceSecret   string            `boil:"service_secret" json:"service_secret" toml:"service_secret" yaml:"service_secret"`
-	AppKey          string            `boil:"app_key" json:"app_key" toml:"app_key" yaml:"app_key"`
-	AppSecret       string            `boil:"app_secret" json:"app_secret" toml:"app_secret" yaml:"app_secret"`
-	RefreshInterval int32             `boil:"refresh_interval" json:"refresh_interval" toml:"refresh_interval" yaml:"refresh_interval"`
-	RequestTimeout  int32             `boil:"request_timeout" json:"request_timeout" toml:"request_timeout" yaml:"request_timeout"`
-	AssetFilter     null.JSON         `boil:"asset_filter" json:"asset_filter,omitempty" toml:"asset_filter" yaml:"asset_filter,omitempty"`
-	Active          null.Bool         `boil:"active" json:"active,omitempty" toml:"active" yaml:"active,omitempty"`
-	Enable          null.Bool         `boil:"enable" json:"enable,omitempty" toml:"enable" yaml:"enable,omitempty"`
-	ProjectIds      types.StringArray `boil:"project_ids" json:"project_ids,omitempty" toml:"project_ids" yaml:"project_ids,omitempty"`
-	UserID          null.String       `boil:"user_id" json:"user_id,omitempty" toml:"user_id" yaml:"user_id,omitempty"`
+	ID                         int64             `boil:"id" json:"id" toml:"id" yaml:"id"`
+	BaseURL                    string            `boil:"base_url" json:"base_url" toml:"base_url" yaml:"base_url"`
+	Service                    string            `boil:"service" json:"service" toml:"service" yaml:"service"`
+	ServiceID                  string            `boil:"service_id" json:"service_id" toml:"service_id" yaml:"service_id"`
+	ServiceSecret              string            `boil:"service_secret" json:"service_secret" toml:"service_secret" yaml:"service_secret"`
+	AppKey                     string            `boil:"app_key" json:"app_key" toml:"app_key" yaml:"app_key"`
+	AppSecret                  string            `boil:"app_secret" json:"app_secret" toml:"app_secret" yaml:"app_secret"`
+	RefreshInterval            int32             `boil:"refresh_interval" json:"refresh_interval" toml:"refresh_interval" yaml:"refresh_interval"`
+	RequestTimeout             int32             `boil:"request_timeout" json:"request_timeout" toml:"request_timeout" yaml:"request_timeout"`
+	AssetFilter                null.JSON         `boil:"asset_filter" json:"asset_filter,omitempty" toml:"asset_filter" yaml:"asset_filter,omitempty"`
+	Active                     null.Bool         `boil:"active" json:"active,omitempty" toml:"active" yaml:"active,omitempty"`
+	Enable                     null.Bool         `boil:"enable" json:"enable,omitempty" toml:"enable" yaml:"enable,omitempty"`
+	ProjectIds                 types.StringArray `boil:"project_ids" json:"project_ids,omitempty" toml:"project_ids" yaml:"project_ids,omitempty"`
+	UserID                     null.String       `boil:"user_id" json:"user_id,omitempty" toml:"user_id" yaml:"user_id,omitempty"`
+	ExcludedUuids              types.StringArray `boil:"excluded_uuids" json:"excluded_uuids,omitempty" toml:"excluded_uuids" yaml:"excluded_uuids,omitempty"`
+	ProjectBindings            null.JSON         `boil:"project_bindings" json:"project_bindings,omitempty" toml:"project_bindings" yaml:"project_bindings,omitempty"`
+	StaleThresholds            null.JSON         `boil:"stale_thresholds" json:"stale_thresholds,omitempty" toml:"stale_thresholds" yaml:"stale_thresholds,omitempty"`
+	StaleOccupancyUnknown      null.Bool         `boil:"stale_occupancy_unknown" json:"stale_occupancy_unknown,omitempty" toml:"stale_occupancy_unknown" yaml:"stale_occupancy_unknown,omitempty"`
+	AlarmTemplates             null.JSON         `boil:"alarm_templates" json:"alarm_templates,omitempty" toml:"alarm_templates" yaml:"alarm_templates,omitempty"`
+	NotificationRecipients     types.StringArray `boil:"notification_recipients" json:"notification_recipients,omitempty" toml:"notification_recipients" yaml:"notification_recipients,omitempty"`
+	NotificationDigestInterval null.Int32        `boil:"notification_digest_interval" json:"notification_digest_interval,omitempty" toml:"notification_digest_interval" yaml:"notification_digest_interval,omitempty"`
 
 	R *configurationR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L configurationL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var ConfigurationColumns = struct {
-	ID              string
-	BaseURL         string
-	Service         string
-	ServiceID       string
-	ServiceSecret   string
-	AppKey          string
-	AppSecret       string
-	RefreshInterval string
-	RequestTimeout  string
-	AssetFilter     string
-	Active          string
-	Enable          string
-	ProjectIds      string
-	UserID          string
+	ID                         string
+	BaseURL                    string
+	Service                    string
+	ServiceID                  string
+	ServiceSecret              string
+	AppKey                     string
+	AppSecret                  string
+	RefreshInterval            string
+	RequestTimeout             string
+	AssetFilter                string
+	Active                     string
+	Enable                     string
+	ProjectIds                 string
+	UserID                     string
+	ExcludedUuids              string
+	ProjectBindings            string
+	StaleThresholds            string
+	StaleOccupancyUnknown      string
+	AlarmTemplates             string
+	NotificationRecipients     string
+	NotificationDigestInterval string
 }{
-	ID:              "id",
-	BaseURL:         "base_url",
-	Service:         "service",
-	ServiceID:       "service_id",
-	ServiceSecret:   "service_secret",
-	AppKey:          "app_key",
-	AppSecret:       "app_secret",
-	RefreshInterval: "refresh_interval",
-	RequestTimeout:  "request_timeout",
-	AssetFilter:     "asset_filter",
-	Active:          "active",
-	Enable:          "enable",
-	ProjectIds:      "project_ids",
-	UserID:          "user_id",
+	ID:                         "id",
+	BaseURL:                    "base_url",
+	Service:                    "service",
+	ServiceID:                  "service_id",
+	ServiceSecret:              "service_secret",
+	AppKey:                     "app_key",
+	AppSecret:                  "app_secret",
+	RefreshInterval:            "refresh_interval",
+	RequestTimeout:             "request_timeout",
+	AssetFilter:                "asset_filter",
+	Active:                     "active",
+	Enable:                     "enable",
+	ProjectIds:                 "project_ids",
+	UserID:                     "user_id",
+	ExcludedUuids:              "excluded_uuids",
+	ProjectBindings:            "project_bindings",
+	StaleThresholds:            "stale_thresholds",
+	StaleOccupancyUnknown:      "stale_occupancy_unknown",
+	AlarmTemplates:             "alarm_templates",
+	NotificationRecipients:     "notification_recipients",
+	NotificationDigestInterval: "notification_digest_interval",
 }
 
 var ConfigurationTableColumns = struct {
-	ID              string
-	BaseURL         string
-	Service         string
-	ServiceID       string
-	ServiceSecret   string
-	AppKey          string
-	AppSecret       string
-	RefreshInterval string
-	RequestTimeout  string
-	AssetFilter     string
-	Active          string
-	Enable          string
-	ProjectIds      string
-	UserID          string
+	ID                         string
+	BaseURL                    string
+	Service                    string
+	ServiceID                  string
+	ServiceSecret              string
+	AppKey                     string
+	AppSecret                  string
+	RefreshInterval            string
+	RequestTimeout             string
+	AssetFilter                string
+	Active                     string
+	Enable                     string
+	ProjectIds                 string
+	UserID                     string
+	ExcludedUuids              string
+	ProjectBindings            string
+	StaleThresholds            string
+	StaleOccupancyUnknown      string
+	AlarmTemplates             string
+	NotificationRecipients     string
+	NotificationDigestInterval string
 }{
-	ID:              "configuration.id",
-	BaseURL:         "configuration.base_url",
-	Service:         "configuration.service",
-	ServiceID:       "configuration.service_id",
-	ServiceSecret:   "configuration.service_secret",
-	AppKey:          "configuration.app_key",
-	AppSecret:       "configuration.app_secret",
-	RefreshInterval: "configuration.refresh_interval",
-	RequestTimeout:  "configuration.request_timeout",
-	AssetFilter:     "configuration.asset_filter",
-	Active:          "configuration.active",
-	Enable:          "configuration.enable",
-	ProjectIds:      "configuration.project_ids",
-	UserID:          "configuration.user_id",
+	ID:                         "configuration.id",
+	BaseURL:                    "configuration.base_url",
+	Service:                    "configuration.service",
+	ServiceID:                  "configuration.service_id",
+	ServiceSecret:              "configuration.service_secret",
+	AppKey:                     "configuration.app_key",
+	AppSecret:                  "configuration.app_secret",
+	RefreshInterval:            "configuration.refresh_interval",
+	RequestTimeout:             "configuration.request_timeout",
+	AssetFilter:                "configuration.asset_filter",
+	Active:                     "configuration.active",
+	Enable:                     "configuration.enable",
+	ProjectIds:                 "configuration.project_ids",
+	UserID:                     "configuration.user_id",
+	ExcludedUuids:              "configuration.excluded_uuids",
+	ProjectBindings:            "configuration.project_bindings",
+	StaleThresholds:            "configuration.stale_thresholds",
+	StaleOccupancyUnknown:      "configuration.stale_occupancy_unknown",
+	AlarmTemplates:             "configuration.alarm_templates",
+	NotificationRecipients:     "configuration.notification_recipients",
+	NotificationDigestInterval: "configuration.notification_digest_interval",
 }
 
 // Generated where
 
-type whereHelperint32 struct{ field string }
-
-func (w whereHelperint32) EQ(x int32) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint32) NEQ(x int32) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint32) LT(x int32) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint32) LTE(x int32) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint32) GT(x int32) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint32) GTE(x int32) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint32) IN(slice []int32) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperint32) NIN(slice []int32) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
 type whereHelpernull_JSON struct{ field string }
 
 func (w whereHelpernull_JSON) EQ(x null.JSON) qm.QueryMod {
@@ -208,47 +220,67 @@ func (w whereHelpertypes_StringArray) IsNotNull() qm.QueryMod {
 }
 
 var ConfigurationWhere = struct {
-	ID              whereHelperint64
-	BaseURL         whereHelperstring
-	Service         whereHelperstring
-	ServiceID       whereHelperstring
-	ServiceSecret   whereHelperstring
-	AppKey          whereHelperstring
-	AppSecret       whereHelperstring
-	RefreshInterval whereHelperint32
-	RequestTimeout  whereHelperint32
-	AssetFilter     whereHelpernull_JSON
-	Active          whereHelpernull_Bool
-	Enable          whereHelpernull_Bool
-	ProjectIds      whereHelpertypes_StringArray
-	UserID          whereHelpernull_String
+	ID                         whereHelperint64
+	BaseURL                    whereHelperstring
+	Service                    whereHelperstring
+	ServiceID                  whereHelperstring
+	ServiceSecret              whereHelperstring
+	AppKey                     whereHelperstring
+	AppSecret                  whereHelperstring
+	RefreshInterval            whereHelperint32
+	RequestTimeout             whereHelperint32
+	AssetFilter                whereHelpernull_JSON
+	Active                     whereHelpernull_Bool
+	Enable                     whereHelpernull_Bool
+	ProjectIds                 whereHelpertypes_StringArray
+	UserID                     whereHelpernull_String
+	ExcludedUuids              whereHelpertypes_StringArray
+	ProjectBindings            whereHelpernull_JSON
+	StaleThresholds            whereHelpernull_JSON
+	StaleOccupancyUnknown      whereHelpernull_Bool
+	AlarmTemplates             whereHelpernull_JSON
+	NotificationRecipients     whereHelpertypes_StringArray
+	NotificationDigestInterval whereHelpernull_Int32
 }{
-	ID:              whereHelperint64{field: "\"signify\".\"configuration\".\"id\""},
-	BaseURL:         whereHelperstring{field: "\"signify\".\"configuration\".\"base_url\""},
-	Service:         whereHelperstring{field: "\"signify\".\"configuration\".\"service\""},
-	ServiceID:       whereHelperstring{field: "\"signify\".\"configuration\".\"service_id\""},
-	ServiceSecret:   whereHelperstring{field: "\"signify\".\"configuration\".\"service_secret\""},
-	AppKey:          whereHelperstring{field: "\"signify\".\"configuration\".\"app_key\""},
-	AppSecret:       whereHelperstring{field: "\"signify\".\"configuration\".\"app_secret\""},
-	RefreshInterval: whereHelperint32{field: "\"signify\".\"configuration\".\"refresh_interval\""},
-	RequestTimeout:  whereHelperint32{field: "\"signify\".\"configuration\".\"request_timeout\""},
-	AssetFilter:     whereHelpernull_JSON{field: "\"signify\".\"configuration\".\"asset_filter\""},
-	Active:          whereHelpernull_Bool{field: "\"signify\".\"configuration\".\"active\""},
-	Enable:          whereHelpernull_Bool{field: "\"signify\".\"configuration\".\"enable\""},
-	ProjectIds:      whereHelpertypes_StringArray{field: "\"signify\".\"configuration\".\"project_ids\""},
-	UserID:          whereHelpernull_String{field: "\"signify\".\"configuration\".\"user_id\""},
+	ID:                         whereHelperint64{field: "\"signify\".\"configuration\".\"id\""},
+	BaseURL:                    whereHelperstring{field: "\"signify\".\"configuration\".\"base_url\""},
+	Service:                    whereHelperstring{field: "\"signify\".\"configuration\".\"service\""},
+	ServiceID:                  whereHelperstring{field: "\"signify\".\"configuration\".\"service_id\""},
+	ServiceSecret:              whereHelperstring{field: "\"signify\".\"configuration\".\"service_secret\""},
+	AppKey:                     whereHelperstring{field: "\"signify\".\"configuration\".\"app_key\""},
+	AppSecret:                  whereHelperstring{field: "\"signify\".\"configuration\".\"app_secret\""},
+	RefreshInterval:            whereHelperint32{field: "\"signify\".\"configuration\".\"refresh_interval\""},
+	RequestTimeout:             whereHelperint32{field: "\"signify\".\"configuration\".\"request_timeout\""},
+	AssetFilter:                whereHelpernull_JSON{field: "\"signify\".\"configuration\".\"asset_filter\""},
+	Active:                     whereHelpernull_Bool{field: "\"signify\".\"configuration\".\"active\""},
+	Enable:                     whereHelpernull_Bool{field: "\"signify\".\"configuration\".\"enable\""},
+	ProjectIds:                 whereHelpertypes_StringArray{field: "\"signify\".\"configuration\".\"project_ids\""},
+	UserID:                     whereHelpernull_String{field: "\"signify\".\"configuration\".\"user_id\""},
+	ExcludedUuids:              whereHelpertypes_StringArray{field: "\"signify\".\"configuration\".\"excluded_uuids\""},
+	ProjectBindings:            whereHelpernull_JSON{field: "\"signify\".\"configuration\".\"project_bindings\""},
+	StaleThresholds:            whereHelpernull_JSON{field: "\"signify\".\"configuration\".\"stale_thresholds\""},
+	StaleOccupancyUnknown:      whereHelpernull_Bool{field: "\"signify\".\"configuration\".\"stale_occupancy_unknown\""},
+	AlarmTemplates:             whereHelpernull_JSON{field: "\"signify\".\"configuration\".\"alarm_templates\""},
+	NotificationRecipients:     whereHelpertypes_StringArray{field: "\"signify\".\"configuration\".\"notification_recipients\""},
+	NotificationDigestInterval: whereHelpernull_Int32{field: "\"signify\".\"configuration\".\"notification_digest_interval\""},
 }
 
 // ConfigurationRels is where relationship names are stored.
 var ConfigurationRels = struct {
-	Assets string
+	AlarmRules string
+	Assets     string
+	SyncJobs   string
 }{
-	Assets: "Assets",
+	AlarmRules: "AlarmRules",
+	Assets:     "Assets",
+	SyncJobs:   "SyncJobs",
 }
 
 // configurationR is where relationships are stored.
 type configurationR struct {
-	Assets AssetSlice `boil:"Assets" json:"Assets" toml:"Assets" yaml:"Assets"`
+	AlarmRules AlarmRuleSlice `boil:"AlarmRules" json:"AlarmRules" toml:"AlarmRules" yaml:"AlarmRules"`
+	Assets     AssetSlice     `boil:"Assets" json:"Assets" toml:"Assets" yaml:"Assets"`
+	SyncJobs   SyncJobSlice   `boil:"SyncJobs" json:"SyncJobs" toml:"SyncJobs" yaml:"SyncJobs"`
 }
 
 // NewStruct creates a new relationship struct
@@ -256,6 +288,13 @@ func (*configurationR) NewStruct() *configurationR {
 	return &configurationR{}
 }
 
+func (r *configurationR) GetAlarmRules() AlarmRuleSlice {
+	if r == nil {
+		return nil
+	}
+	return r.AlarmRules
+}
+
 func (r *configurationR) GetAssets() AssetSlice {
 	if r == nil {
 		return nil
@@ -263,13 +302,20 @@ func (r *configurationR) GetAssets() AssetSlice {
 	return r.Assets
 }
 
+func (r *configurationR) GetSyncJobs() SyncJobSlice {
+	if r == nil {
+		return nil
+	}
+	return r.SyncJobs
+}
+
 // configurationL is where Load methods for each relationship are stored.
 type configurationL struct{}
 
 var (
-	configurationAllColumns            = []string{"id", "base_url", "service", "service_id", "service_secret", "app_key", "app_secret", "refresh_interval", "request_timeout", "asset_filter", "active", "enable", "project_ids", "user_id"}
+	configurationAllColumns            = []string{"id", "base_url", "service", "service_id", "service_secret", "app_key", "app_secret", "refresh_interval", "request_timeout", "asset_filter", "active", "enable", "project_ids", "user_id", "excluded_uuids", "project_bindings", "stale_thresholds", "stale_occupancy_unknown", "alarm_templates", "notification_recipients", "notification_digest_interval"}
 	configurationColumnsWithoutDefault = []string{"base_url", "service", "service_id", "service_secret", "app_key", "app_secret"}
-	configurationColumnsWithDefault    = []string{"id", "refresh_interval", "request_timeout", "asset_filter", "active", "enable", "project_ids", "user_id"}
+	configurationColumnsWithDefault    = []string{"id", "refresh_interval", "request_timeout", "asset_filter", "active", "enable", "project_ids", "user_id", "excluded_uuids", "project_bindings", "stale_thresholds", "stale_occupancy_unknown", "alarm_templates", "notification_recipients", "notification_digest_interval"}
 	configurationPrimaryKeyColumns     = []string{"id"}
 	configurationGeneratedColumns      = []string{}
 )
@@ -599,6 +645,20 @@ func (q configurationQuery) Exists(ctx context.Context, exec boil.ContextExecuto
 	return count > 0, nil
 }
 
+// AlarmRules retrieves all the alarm_rule's AlarmRules with an executor.
+func (o *Configuration) AlarmRules(mods ...qm.QueryMod) alarmRuleQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"signify\".\"alarm_rule\".\"configuration_id\"=?", o.ID),
+	)
+
+	return AlarmRules(queryMods...)
+}
+
 // Assets retrieves all the asset's Assets with an executor.
 func (o *Configuration) Assets(mods ...qm.QueryMod) assetQuery {
 	var queryMods []qm.QueryMod
@@ -613,6 +673,133 @@ func (o *Configuration) Assets(mods ...qm.QueryMod) assetQuery {
 	return Assets(queryMods...)
 }
 
+// SyncJobs retrieves all the sync_job's SyncJobs with an executor.
+func (o *Configuration) SyncJobs(mods ...qm.QueryMod) syncJobQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"signify\".\"sync_job\".\"configuration_id\"=?", o.ID),
+	)
+
+	return SyncJobs(queryMods...)
+}
+
+// LoadAlarmRules allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (configurationL) LoadAlarmRules(ctx context.Context, e boil.ContextExecutor, singular bool, maybeConfiguration interface{}, mods queries.Applicator) error {
+	var slice []*Configuration
+	var object *Configuration
+
+	if singular {
+		var ok bool
+		object, ok = maybeConfiguration.(*Configuration)
+		if !ok {
+			object = new(Configuration)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeConfiguration)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeConfiguration))
+			}
+		}
+	} else {
+		s, ok := maybeConfiguration.(*[]*Configuration)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeConfiguration)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeConfiguration))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &configurationR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &configurationR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`signify.alarm_rule`),
+		qm.WhereIn(`signify.alarm_rule.configuration_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load alarm_rule")
+	}
+
+	var resultSlice []*AlarmRule
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice alarm_rule")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on alarm_rule")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for alarm_rule")
+	}
+
+	if len(alarmRuleAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.AlarmRules = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &alarmRuleR{}
+			}
+			foreign.R.Configuration = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ConfigurationID {
+				local.R.AlarmRules = append(local.R.AlarmRules, foreign)
+				if foreign.R == nil {
+					foreign.R = &alarmRuleR{}
+				}
+				foreign.R.Configuration = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadAssets allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (configurationL) LoadAssets(ctx context.Context, e boil.ContextExecutor, singular bool, maybeConfiguration interface{}, mods queries.Applicator) error {
@@ -726,6 +913,181 @@ func (configurationL) LoadAssets(ctx context.Context, e boil.ContextExecutor, si
 	return nil
 }
 
+// LoadSyncJobs allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (configurationL) LoadSyncJobs(ctx context.Context, e boil.ContextExecutor, singular bool, maybeConfiguration interface{}, mods queries.Applicator) error {
+	var slice []*Configuration
+	var object *Configuration
+
+	if singular {
+		var ok bool
+		object, ok = maybeConfiguration.(*Configuration)
+		if !ok {
+			object = new(Configuration)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeConfiguration)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeConfiguration))
+			}
+		}
+	} else {
+		s, ok := maybeConfiguration.(*[]*Configuration)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeConfiguration)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeConfiguration))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &configurationR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &configurationR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`signify.sync_job`),
+		qm.WhereIn(`signify.sync_job.configuration_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load sync_job")
+	}
+
+	var resultSlice []*SyncJob
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice sync_job")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on sync_job")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for sync_job")
+	}
+
+	if len(syncJobAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.SyncJobs = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &syncJobR{}
+			}
+			foreign.R.Configuration = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ConfigurationID {
+				local.R.SyncJobs = append(local.R.SyncJobs, foreign)
+				if foreign.R == nil {
+					foreign.R = &syncJobR{}
+				}
+				foreign.R.Configuration = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddAlarmRulesG adds the given related objects to the existing relationships
+// of the configuration, optionally inserting them as new records.
+// Appends related to o.R.AlarmRules.
+// Sets related.R.Configuration appropriately.
+// Uses the global database handle.
+func (o *Configuration) AddAlarmRulesG(ctx context.Context, insert bool, related ...*AlarmRule) error {
+	return o.AddAlarmRules(ctx, boil.GetContextDB(), insert, related...)
+}
+
+// AddAlarmRules adds the given related objects to the existing relationships
+// of the configuration, optionally inserting them as new records.
+// Appends related to o.R.AlarmRules.
+// Sets related.R.Configuration appropriately.
+func (o *Configuration) AddAlarmRules(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*AlarmRule) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ConfigurationID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"signify\".\"alarm_rule\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"configuration_id"}),
+				strmangle.WhereClause("\"", "\"", 2, alarmRulePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ConfigurationID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &configurationR{
+			AlarmRules: related,
+		}
+	} else {
+		o.R.AlarmRules = append(o.R.AlarmRules, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &alarmRuleR{
+				Configuration: o,
+			}
+		} else {
+			rel.R.Configuration = o
+		}
+	}
+	return nil
+}
+
 // AddAssetsG adds the given related objects to the existing relationships
 // of the configuration, optionally inserting them as new records.
 // Appends related to o.R.Assets.
@@ -788,6 +1150,68 @@ func (o *Configuration) AddAssets(ctx context.Context, exec boil.ContextExecutor
 	return nil
 }
 
+// AddSyncJobsG adds the given related objects to the existing relationships
+// of the configuration, optionally inserting them as new records.
+// Appends related to o.R.SyncJobs.
+// Sets related.R.Configuration appropriately.
+// Uses the global database handle.
+func (o *Configuration) AddSyncJobsG(ctx context.Context, insert bool, related ...*SyncJob) error {
+	return o.AddSyncJobs(ctx, boil.GetContextDB(), insert, related...)
+}
+
+// AddSyncJobs adds the given related objects to the existing relationships
+// of the configuration, optionally inserting them as new records.
+// Appends related to o.R.SyncJobs.
+// Sets related.R.Configuration appropriately.
+func (o *Configuration) AddSyncJobs(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*SyncJob) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ConfigurationID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"signify\".\"sync_job\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"configuration_id"}),
+				strmangle.WhereClause("\"", "\"", 2, syncJobPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ConfigurationID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &configurationR{
+			SyncJobs: related,
+		}
+	} else {
+		o.R.SyncJobs = append(o.R.SyncJobs, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &syncJobR{
+				Configuration: o,
+			}
+		} else {
+			rel.R.Configuration = o
+		}
+	}
+	return nil
+}
+
 // Configurations retrieves all the records using an executor.
 func Configurations(mods ...qm.QueryMod) configurationQuery {
 	mods = append(mods, qm.From("\"signify\".\"configuration\""))
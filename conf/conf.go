@@ -17,13 +17,16 @@ package conf
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/eliona-smart-building-assistant/go-eliona/frontend"
+	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"signify/apiserver"
 	"signify/appdb"
+	"time"
 
 	"github.com/eliona-smart-building-assistant/go-utils/common"
 	"github.com/volatiletech/null/v8"
@@ -31,6 +34,7 @@ import (
 )
 
 var ErrBadRequest = errors.New("bad request")
+var ErrNotFound = errors.New("not found")
 
 func InsertConfig(ctx context.Context, config apiserver.Configuration) (apiserver.Configuration, error) {
 	dbConfig, err := dbConfigFromApiConfig(ctx, config)
@@ -47,11 +51,14 @@ func GetConfig(ctx context.Context, configID int64) (*apiserver.Configuration, e
 	dbConfig, err := appdb.Configurations(
 		appdb.ConfigurationWhere.ID.EQ(configID),
 	).OneG(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
 	if err != nil {
 		return nil, fmt.Errorf("fetching config from database: %v", err)
 	}
 	if dbConfig == nil {
-		return nil, ErrBadRequest
+		return nil, ErrNotFound
 	}
 	apiConfig, err := apiConfigFromDbConfig(dbConfig)
 	if err != nil {
@@ -66,6 +73,11 @@ func DeleteConfig(ctx context.Context, configID int64) error {
 	).DeleteAllG(ctx); err != nil {
 		return fmt.Errorf("deleting assets from database: %v", err)
 	}
+	if _, err := appdb.AlarmRules(
+		appdb.AlarmRuleWhere.ConfigurationID.EQ(configID),
+	).DeleteAllG(ctx); err != nil {
+		return fmt.Errorf("deleting alarm rules from database: %v", err)
+	}
 	count, err := appdb.Configurations(
 		appdb.ConfigurationWhere.ID.EQ(configID),
 	).DeleteAllG(ctx)
@@ -76,7 +88,7 @@ func DeleteConfig(ctx context.Context, configID int64) error {
 		return fmt.Errorf("shouldn't happen: deleted more (%v) configs by ID", count)
 	}
 	if count == 0 {
-		return ErrBadRequest
+		return ErrNotFound
 	}
 	return nil
 }
@@ -110,10 +122,39 @@ func dbConfigFromApiConfig(ctx context.Context, apiConfig apiserver.Configuratio
 		return appdb.Configuration{}, fmt.Errorf("marshalling assetFilter: %v", err)
 	}
 	dbConfig.AssetFilter = null.JSONFrom(af)
+	if apiConfig.ProjectBindings != nil {
+		pb, err := json.Marshal(apiConfig.ProjectBindings)
+		if err != nil {
+			return appdb.Configuration{}, fmt.Errorf("marshalling projectBindings: %v", err)
+		}
+		dbConfig.ProjectBindings = null.JSONFrom(pb)
+	}
 	dbConfig.Active = null.BoolFromPtr(apiConfig.Active)
 	if apiConfig.ProjectIDs != nil {
 		dbConfig.ProjectIds = *apiConfig.ProjectIDs
 	}
+	if apiConfig.ExcludedUUIDs != nil {
+		dbConfig.ExcludedUuids = *apiConfig.ExcludedUUIDs
+	}
+	if apiConfig.StaleThresholds != nil {
+		st, err := json.Marshal(apiConfig.StaleThresholds)
+		if err != nil {
+			return appdb.Configuration{}, fmt.Errorf("marshalling staleThresholds: %v", err)
+		}
+		dbConfig.StaleThresholds = null.JSONFrom(st)
+	}
+	dbConfig.StaleOccupancyUnknown = null.BoolFromPtr(apiConfig.StaleOccupancyUnknown)
+	if apiConfig.NotificationRecipients != nil {
+		dbConfig.NotificationRecipients = *apiConfig.NotificationRecipients
+	}
+	dbConfig.NotificationDigestInterval = null.Int32FromPtr(apiConfig.NotificationDigestInterval)
+	if apiConfig.AlarmTemplates != nil {
+		at, err := json.Marshal(apiConfig.AlarmTemplates)
+		if err != nil {
+			return appdb.Configuration{}, fmt.Errorf("marshalling alarmTemplates: %v", err)
+		}
+		dbConfig.AlarmTemplates = null.JSONFrom(at)
+	}
 
 	env := frontend.GetEnvironment(ctx)
 	if env != nil {
@@ -141,8 +182,33 @@ func apiConfigFromDbConfig(dbConfig *appdb.Configuration) (apiConfig apiserver.C
 		}
 		apiConfig.AssetFilter = af
 	}
+	if dbConfig.ProjectBindings.Valid {
+		var pb []apiserver.ProjectBinding
+		if err := json.Unmarshal(dbConfig.ProjectBindings.JSON, &pb); err != nil {
+			return apiserver.Configuration{}, fmt.Errorf("unmarshalling projectBindings: %v", err)
+		}
+		apiConfig.ProjectBindings = &pb
+	}
 	apiConfig.Active = dbConfig.Active.Ptr()
 	apiConfig.ProjectIDs = common.Ptr[[]string](dbConfig.ProjectIds)
+	apiConfig.ExcludedUUIDs = common.Ptr[[]string](dbConfig.ExcludedUuids)
+	if dbConfig.StaleThresholds.Valid {
+		var st map[string]int32
+		if err := json.Unmarshal(dbConfig.StaleThresholds.JSON, &st); err != nil {
+			return apiserver.Configuration{}, fmt.Errorf("unmarshalling staleThresholds: %v", err)
+		}
+		apiConfig.StaleThresholds = st
+	}
+	apiConfig.StaleOccupancyUnknown = dbConfig.StaleOccupancyUnknown.Ptr()
+	apiConfig.NotificationRecipients = common.Ptr[[]string](dbConfig.NotificationRecipients)
+	apiConfig.NotificationDigestInterval = dbConfig.NotificationDigestInterval.Ptr()
+	if dbConfig.AlarmTemplates.Valid {
+		var at []apiserver.AlarmTemplate
+		if err := json.Unmarshal(dbConfig.AlarmTemplates.JSON, &at); err != nil {
+			return apiserver.Configuration{}, fmt.Errorf("unmarshalling alarmTemplates: %v", err)
+		}
+		apiConfig.AlarmTemplates = &at
+	}
 	apiConfig.UserId = dbConfig.UserID.Ptr()
 	return apiConfig, nil
 }
@@ -178,6 +244,95 @@ func ProjIds(config apiserver.Configuration) []string {
 	return *config.ProjectIDs
 }
 
+// ProjectBindings returns the bindings of all projects of the configuration. Projects listed in the project IDs
+// without own binding are bound without additional filter.
+func ProjectBindings(config apiserver.Configuration) []apiserver.ProjectBinding {
+	var bindings []apiserver.ProjectBinding
+	bound := make(map[string]bool)
+	if config.ProjectBindings != nil {
+		for _, binding := range *config.ProjectBindings {
+			if bound[binding.ProjectId] {
+				continue
+			}
+			bound[binding.ProjectId] = true
+			bindings = append(bindings, binding)
+		}
+	}
+	for _, projectId := range ProjIds(config) {
+		if bound[projectId] {
+			continue
+		}
+		bound[projectId] = true
+		bindings = append(bindings, apiserver.ProjectBinding{ProjectId: projectId})
+	}
+	return bindings
+}
+
+// RootAssetName returns the name of the root asset in the project of the binding
+func RootAssetName(binding apiserver.ProjectBinding) string {
+	if binding.RootAssetName == nil || *binding.RootAssetName == "" {
+		return "Signify"
+	}
+	return *binding.RootAssetName
+}
+
+// LocationalParents returns the IDs of the existing Eliona assets by the UUID of the attached site or building
+func LocationalParents(binding apiserver.ProjectBinding) map[string]int32 {
+	parents := make(map[string]int32)
+	for _, parent := range binding.LocationalParents {
+		parents[parent.Uuid] = parent.AssetId
+	}
+	return parents
+}
+
+func ExcludedUUIDs(config apiserver.Configuration) []string {
+	if config.ExcludedUUIDs == nil {
+		return []string{}
+	}
+	return *config.ExcludedUUIDs
+}
+
+// StaleThresholds returns the durations without a message after which spaces are stale, by subscription type
+func StaleThresholds(config apiserver.Configuration) map[string]time.Duration {
+	thresholds := make(map[string]time.Duration)
+	for subscriptionType, seconds := range config.StaleThresholds {
+		if seconds > 0 {
+			thresholds[subscriptionType] = time.Duration(seconds) * time.Second
+		}
+	}
+	return thresholds
+}
+
+func IsStaleOccupancyUnknown(config apiserver.Configuration) bool {
+	return config.StaleOccupancyUnknown != nil && *config.StaleOccupancyUnknown
+}
+
+func AlarmTemplates(config apiserver.Configuration) []apiserver.AlarmTemplate {
+	if config.AlarmTemplates == nil {
+		return nil
+	}
+	return *config.AlarmTemplates
+}
+
+// NotificationRecipients returns the users notified about the configuration. If no recipients are set,
+// the user who last created or updated the configuration is notified.
+func NotificationRecipients(config apiserver.Configuration) []string {
+	if config.NotificationRecipients != nil && len(*config.NotificationRecipients) > 0 {
+		return *config.NotificationRecipients
+	}
+	if config.UserId != nil {
+		return []string{*config.UserId}
+	}
+	return nil
+}
+
+func NotificationDigestInterval(config apiserver.Configuration) time.Duration {
+	if config.NotificationDigestInterval == nil {
+		return 0
+	}
+	return time.Duration(*config.NotificationDigestInterval) * time.Second
+}
+
 func IsConfigActive(config apiserver.Configuration) bool {
 	return config.Active == nil || *config.Active
 }
@@ -200,9 +355,23 @@ const (
 	BuildingAssetKind AssetKind = "building"
 	StoreyAssetKind   AssetKind = "storey"
 	SpaceAssetKind    AssetKind = "space"
+
+	FunctionalGroupAssetKind AssetKind = "functional_group"
 )
 
-func InsertAsset(ctx context.Context, config apiserver.Configuration, projId string, uuid string, parentUUID *string, globalAssetID string, kind AssetKind, assetId int32) error {
+// InsertAsset maps an object to the Eliona asset created for it. The asset type and name are kept with the mapping,
+// so dashboards can be built without reading the assets from Eliona. The definition holds the fields written to
+// Eliona, so the asset is only written again if they change.
+func InsertAsset(ctx context.Context, config apiserver.Configuration, projId string, uuid string, parentUUID *string, globalAssetID string, kind AssetKind, assetId int32, assetType string, name string, definition string) error {
+	return insertAsset(ctx, config, projId, uuid, parentUUID, globalAssetID, kind, assetId, false, null.StringFrom(assetType), null.StringFrom(name), null.StringFrom(definition))
+}
+
+// InsertAttachedAsset maps an object to an existing Eliona asset, which was not created by the app
+func InsertAttachedAsset(ctx context.Context, config apiserver.Configuration, projId string, uuid string, parentUUID *string, globalAssetID string, kind AssetKind, assetId int32) error {
+	return insertAsset(ctx, config, projId, uuid, parentUUID, globalAssetID, kind, assetId, true, null.String{}, null.String{}, null.String{})
+}
+
+func insertAsset(ctx context.Context, config apiserver.Configuration, projId string, uuid string, parentUUID *string, globalAssetID string, kind AssetKind, assetId int32, attached bool, assetType null.String, name null.String, definition null.String) error {
 	var dbAsset appdb.Asset
 	dbAsset.ConfigurationID = null.Int64FromPtr(config.Id).Int64
 	dbAsset.ProjectID = projId
@@ -211,9 +380,65 @@ func InsertAsset(ctx context.Context, config apiserver.Configuration, projId str
 	dbAsset.Kind = string(kind)
 	dbAsset.GlobalAssetID = globalAssetID
 	dbAsset.AssetID = null.Int32From(assetId)
+	dbAsset.Attached = attached
+	dbAsset.AssetType = assetType
+	dbAsset.Name = name
+	dbAsset.Definition = definition
 	return dbAsset.InsertG(ctx, boil.Infer())
 }
 
+// SetAssetDefinition updates the asset type, name and definition kept with a mapping after the asset was written
+// to Eliona. Mappings inserted before they were kept are completed this way.
+func SetAssetDefinition(ctx context.Context, dbAsset *appdb.Asset, assetType string, name string, definition string) error {
+	dbAsset.AssetType = null.StringFrom(assetType)
+	dbAsset.Name = null.StringFrom(name)
+	dbAsset.Definition = null.StringFrom(definition)
+	_, err := dbAsset.UpdateG(ctx, boil.Whitelist(appdb.AssetColumns.AssetType, appdb.AssetColumns.Name, appdb.AssetColumns.Definition))
+	return err
+}
+
+func GetAssetWithGAI(ctx context.Context, config apiserver.Configuration, projId string, globalAssetID string) (*appdb.Asset, error) {
+	dbAssets, err := appdb.Assets(
+		appdb.AssetWhere.ConfigurationID.EQ(null.Int64FromPtr(config.Id).Int64),
+		appdb.AssetWhere.ProjectID.EQ(projId),
+		appdb.AssetWhere.GlobalAssetID.EQ(globalAssetID),
+	).AllG(ctx)
+	if err != nil || len(dbAssets) == 0 {
+		return nil, err
+	}
+	return dbAssets[0], nil
+}
+
+// DeleteAssetMappingTree removes the mappings of an object and all its descendants in a project and returns the
+// removed mappings of the descendants. The Eliona assets are not deleted.
+func DeleteAssetMappingTree(ctx context.Context, config apiserver.Configuration, projId string, uuid string) ([]*appdb.Asset, error) {
+	var removed []*appdb.Asset
+	uuids := []string{uuid}
+	for len(uuids) > 0 {
+		children, err := appdb.Assets(
+			appdb.AssetWhere.ConfigurationID.EQ(null.Int64FromPtr(config.Id).Int64),
+			appdb.AssetWhere.ProjectID.EQ(projId),
+			appdb.AssetWhere.ParentUUID.IN(uuids),
+		).AllG(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("fetching child assets from database: %v", err)
+		}
+		if _, err := appdb.Assets(
+			appdb.AssetWhere.ConfigurationID.EQ(null.Int64FromPtr(config.Id).Int64),
+			appdb.AssetWhere.ProjectID.EQ(projId),
+			appdb.AssetWhere.UUID.IN(uuids),
+		).DeleteAllG(ctx); err != nil {
+			return nil, fmt.Errorf("deleting assets from database: %v", err)
+		}
+		removed = append(removed, children...)
+		uuids = nil
+		for _, child := range children {
+			uuids = append(uuids, child.UUID)
+		}
+	}
+	return removed, nil
+}
+
 func GetAssetIdWithGAI(ctx context.Context, config apiserver.Configuration, projId string, globalAssetID string) (*int32, error) {
 	dbAsset, err := appdb.Assets(
 		appdb.AssetWhere.ConfigurationID.EQ(null.Int64FromPtr(config.Id).Int64),
@@ -229,3 +454,293 @@ func GetAssetIdWithGAI(ctx context.Context, config apiserver.Configuration, proj
 func GetAssets(ctx context.Context, mods ...qm.QueryMod) ([]*appdb.Asset, error) {
 	return appdb.Assets(mods...).AllG(ctx)
 }
+
+// GetAssetMappings returns the asset mappings of a configuration. Empty filter values are ignored.
+func GetAssetMappings(ctx context.Context, configID int64, kind string, projectID string, parentUUID string) ([]apiserver.AssetMapping, error) {
+	mods := []qm.QueryMod{
+		appdb.AssetWhere.ConfigurationID.EQ(configID),
+		qm.OrderBy(appdb.AssetColumns.ID),
+	}
+	if kind != "" {
+		mods = append(mods, appdb.AssetWhere.Kind.EQ(kind))
+	}
+	if projectID != "" {
+		mods = append(mods, appdb.AssetWhere.ProjectID.EQ(projectID))
+	}
+	if parentUUID != "" {
+		mods = append(mods, appdb.AssetWhere.ParentUUID.EQ(null.StringFrom(parentUUID)))
+	}
+	dbAssets, err := appdb.Assets(mods...).AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching assets from database: %v", err)
+	}
+	apiMappings := []apiserver.AssetMapping{}
+	for _, dbAsset := range dbAssets {
+		apiMappings = append(apiMappings, apiAssetMappingFromDbAsset(dbAsset))
+	}
+	return apiMappings, nil
+}
+
+func GetAssetMapping(ctx context.Context, id int64) (*apiserver.AssetMapping, error) {
+	dbAsset, err := appdb.Assets(
+		appdb.AssetWhere.ID.EQ(id),
+	).OneG(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching asset from database: %v", err)
+	}
+	return common.Ptr(apiAssetMappingFromDbAsset(dbAsset)), nil
+}
+
+func DeleteAssetMapping(ctx context.Context, id int64) error {
+	count, err := appdb.Assets(
+		appdb.AssetWhere.ID.EQ(id),
+	).DeleteAllG(ctx)
+	if err != nil {
+		return fmt.Errorf("deleting asset from database: %v", err)
+	}
+	if count == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func apiAssetMappingFromDbAsset(dbAsset *appdb.Asset) apiserver.AssetMapping {
+	return apiserver.AssetMapping{
+		Id:            dbAsset.ID,
+		ConfigId:      dbAsset.ConfigurationID,
+		ProjectId:     dbAsset.ProjectID,
+		Kind:          dbAsset.Kind,
+		Uuid:          dbAsset.UUID,
+		ParentUuid:    dbAsset.ParentUUID.Ptr(),
+		GlobalAssetId: dbAsset.GlobalAssetID,
+		AssetId:       dbAsset.AssetID.Ptr(),
+		Attached:      dbAsset.Attached,
+	}
+}
+
+// GetAlarmRules returns the alarm rules created by the app for a configuration
+func GetAlarmRules(ctx context.Context, configID int64, mods ...qm.QueryMod) ([]*appdb.AlarmRule, error) {
+	return appdb.AlarmRules(append(mods, appdb.AlarmRuleWhere.ConfigurationID.EQ(configID))...).AllG(ctx)
+}
+
+// UpsertAlarmRule keeps the id and definition of the alarm rule created for an asset from a template
+func UpsertAlarmRule(ctx context.Context, configID int64, assetId int32, templateName string, alarmRuleId int32, definition string) error {
+	dbAlarmRule := appdb.AlarmRule{
+		ConfigurationID: configID,
+		AssetID:         assetId,
+		TemplateName:    templateName,
+		AlarmRuleID:     alarmRuleId,
+		Definition:      definition,
+	}
+	return dbAlarmRule.UpsertG(ctx, true,
+		[]string{appdb.AlarmRuleColumns.ConfigurationID, appdb.AlarmRuleColumns.AssetID, appdb.AlarmRuleColumns.TemplateName},
+		boil.Whitelist(appdb.AlarmRuleColumns.AlarmRuleID, appdb.AlarmRuleColumns.Definition),
+		boil.Infer(),
+	)
+}
+
+func DeleteAlarmRule(ctx context.Context, dbAlarmRule *appdb.AlarmRule) error {
+	_, err := dbAlarmRule.DeleteG(ctx)
+	return err
+}
+
+// InsertEvent records an event in the event log. Events without configuration have no configId.
+func InsertEvent(ctx context.Context, configID *int64, eventType string, message string) error {
+	dbEvent := appdb.Event{
+		ConfigurationID: null.Int64FromPtr(configID),
+		Type:            eventType,
+		Message:         message,
+	}
+	return dbEvent.InsertG(ctx, boil.Infer())
+}
+
+// GetEvents returns up to limit events of the event log after the event with the cursor id, oldest first. A configID
+// of 0, a zero since and a cursor of 0 don't filter.
+func GetEvents(ctx context.Context, configID int64, since time.Time, cursor int64, limit int32) ([]apiserver.Event, error) {
+	mods := []qm.QueryMod{
+		appdb.EventWhere.ID.GT(cursor),
+		qm.OrderBy(appdb.EventColumns.ID),
+		qm.Limit(int(limit)),
+	}
+	if configID != 0 {
+		mods = append(mods, appdb.EventWhere.ConfigurationID.EQ(null.Int64From(configID)))
+	}
+	if !since.IsZero() {
+		mods = append(mods, appdb.EventWhere.CreatedAt.GTE(since))
+	}
+	dbEvents, err := appdb.Events(mods...).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]apiserver.Event, 0, len(dbEvents))
+	for _, dbEvent := range dbEvents {
+		events = append(events, apiserver.Event{
+			Id:        dbEvent.ID,
+			ConfigId:  dbEvent.ConfigurationID.Ptr(),
+			Type:      dbEvent.Type,
+			Message:   dbEvent.Message,
+			CreatedAt: dbEvent.CreatedAt,
+		})
+	}
+	return events, nil
+}
+
+// DeleteEventsBefore removes the events recorded before the given time
+func DeleteEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	return appdb.Events(appdb.EventWhere.CreatedAt.LT(before)).DeleteAllG(ctx)
+}
+
+func CountAssetsByKind(ctx context.Context, configID int64) (map[AssetKind]int64, error) {
+	dbAssets, err := appdb.Assets(
+		appdb.AssetWhere.ConfigurationID.EQ(configID),
+	).AllG(ctx)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[AssetKind]int64)
+	for _, dbAsset := range dbAssets {
+		counts[AssetKind(dbAsset.Kind)]++
+	}
+	return counts, nil
+}
+
+// PingDatabase checks the connection to the database used by boil
+func PingDatabase(ctx context.Context) error {
+	db, ok := boil.GetContextDB().(interface {
+		PingContext(ctx context.Context) error
+	})
+	if !ok {
+		return errors.New("database connection does not support ping")
+	}
+	return db.PingContext(ctx)
+}
+
+// Status of synchronisation jobs
+const (
+	SyncJobPending   = "pending"
+	SyncJobRunning   = "running"
+	SyncJobSucceeded = "succeeded"
+	SyncJobFailed    = "failed"
+)
+
+// InsertSyncJob enqueues a synchronisation job of a configuration, which is picked up by the instance leading it
+func InsertSyncJob(ctx context.Context, configID int64) (apiserver.SyncJob, error) {
+	dbJob := appdb.SyncJob{
+		ConfigurationID: configID,
+		Status:          SyncJobPending,
+	}
+	if err := dbJob.InsertG(ctx, boil.Infer()); err != nil {
+		return apiserver.SyncJob{}, err
+	}
+	return toAPISyncJob(&dbJob), nil
+}
+
+func GetSyncJob(ctx context.Context, id int64) (*apiserver.SyncJob, error) {
+	dbJob, err := appdb.SyncJobs(
+		appdb.SyncJobWhere.ID.EQ(id),
+	).OneG(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching sync job from database: %v", err)
+	}
+	job := toAPISyncJob(dbJob)
+	return &job, nil
+}
+
+// StartPendingSyncJobs marks the pending jobs of a configuration as running and returns their ids. A job is started
+// only once, even if several instances pick up jobs at the same time.
+func StartPendingSyncJobs(ctx context.Context, configID int64) ([]int64, error) {
+	var dbJobs appdb.SyncJobSlice
+	err := queries.Raw(`update signify.sync_job set status = $1, started_at = now()
+		where configuration_id = $2 and status = $3 returning *`,
+		SyncJobRunning, configID, SyncJobPending,
+	).BindG(ctx, &dbJobs)
+	if err != nil {
+		return nil, err
+	}
+	var ids []int64
+	for _, dbJob := range dbJobs {
+		ids = append(ids, dbJob.ID)
+	}
+	return ids, nil
+}
+
+// RequeueRunningSyncJobs marks the running jobs of a configuration as pending again, e.g. if the instance which
+// started them is gone.
+func RequeueRunningSyncJobs(ctx context.Context, configID int64) error {
+	_, err := appdb.SyncJobs(
+		appdb.SyncJobWhere.ConfigurationID.EQ(configID),
+		appdb.SyncJobWhere.Status.EQ(SyncJobRunning),
+	).UpdateAllG(ctx, appdb.M{
+		appdb.SyncJobColumns.Status:    SyncJobPending,
+		appdb.SyncJobColumns.StartedAt: null.Time{},
+	})
+	return err
+}
+
+// FailPendingSyncJobs finishes the pending jobs of a configuration without running them
+func FailPendingSyncJobs(ctx context.Context, configID int64, reason string) error {
+	_, err := appdb.SyncJobs(
+		appdb.SyncJobWhere.ConfigurationID.EQ(configID),
+		appdb.SyncJobWhere.Status.EQ(SyncJobPending),
+	).UpdateAllG(ctx, appdb.M{
+		appdb.SyncJobColumns.Status:     SyncJobFailed,
+		appdb.SyncJobColumns.FinishedAt: null.TimeFrom(time.Now()),
+		appdb.SyncJobColumns.Error:      null.StringFrom(reason),
+	})
+	return err
+}
+
+// FinishSyncJobs stores the result of the synchronisation run for the given jobs. Only running jobs are finished, so
+// jobs requeued by a new leader meanwhile are left to it.
+func FinishSyncJobs(ctx context.Context, ids []int64, result apiserver.SyncResult, syncErr error) error {
+	columns := appdb.M{
+		appdb.SyncJobColumns.FinishedAt:        null.TimeFrom(time.Now()),
+		appdb.SyncJobColumns.DiscoveredObjects: null.Int32From(result.DiscoveredObjects),
+		appdb.SyncJobColumns.CreatedAssets:     null.Int32From(result.CreatedAssets),
+		appdb.SyncJobColumns.RemovedAssets:     null.Int32From(result.RemovedAssets),
+	}
+	if syncErr != nil {
+		columns[appdb.SyncJobColumns.Status] = SyncJobFailed
+		columns[appdb.SyncJobColumns.Error] = null.StringFrom(syncErr.Error())
+	} else {
+		columns[appdb.SyncJobColumns.Status] = SyncJobSucceeded
+	}
+	_, err := appdb.SyncJobs(
+		appdb.SyncJobWhere.ID.IN(ids),
+		appdb.SyncJobWhere.Status.EQ(SyncJobRunning),
+	).UpdateAllG(ctx, columns)
+	return err
+}
+
+// DeleteSyncJobsBefore removes the jobs finished before the given time
+func DeleteSyncJobsBefore(ctx context.Context, before time.Time) (int64, error) {
+	return appdb.SyncJobs(
+		appdb.SyncJobWhere.FinishedAt.LT(null.TimeFrom(before)),
+	).DeleteAllG(ctx)
+}
+
+func toAPISyncJob(dbJob *appdb.SyncJob) apiserver.SyncJob {
+	job := apiserver.SyncJob{
+		Id:         dbJob.ID,
+		ConfigId:   dbJob.ConfigurationID,
+		Status:     dbJob.Status,
+		CreatedAt:  dbJob.CreatedAt,
+		StartedAt:  dbJob.StartedAt.Ptr(),
+		FinishedAt: dbJob.FinishedAt.Ptr(),
+		Error:      dbJob.Error.Ptr(),
+	}
+	if dbJob.Status == SyncJobSucceeded {
+		job.Result = &apiserver.SyncResult{
+			DiscoveredObjects: dbJob.DiscoveredObjects.Int32,
+			CreatedAssets:     dbJob.CreatedAssets.Int32,
+			RemovedAssets:     dbJob.RemovedAssets.Int32,
+		}
+	}
+	return job
+}
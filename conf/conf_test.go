@@ -0,0 +1,276 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"regexp"
+	"signify/apiserver"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// fakeSyncJobs is a database holding sync jobs, which understands the updates of the sync job functions
+type fakeSyncJobs struct {
+	mutex sync.Mutex
+	jobs  map[int64]map[string]driver.Value
+}
+
+var (
+	setPattern      = regexp.MustCompile(`"?(\w+)"?\s*=\s*(\$\d+|now\(\))`)
+	conditionEquals = regexp.MustCompile(`"?(\w+)"?\s*=\s*\$(\d+)`)
+	conditionIn     = regexp.MustCompile(`"?(\w+)"?\s+IN\s+\(([^)]*)\)`)
+	wherePattern    = regexp.MustCompile(`(?i)\swhere\s`)
+)
+
+// update applies an update statement and returns the ids of the updated jobs
+func (f *fakeSyncJobs) update(query string, args []driver.NamedValue) ([]int64, error) {
+	if !strings.HasPrefix(strings.ToLower(strings.TrimSpace(query)), "update") {
+		return nil, errors.New("unexpected statement " + query)
+	}
+	arg := func(placeholder string) driver.Value {
+		index, _ := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(placeholder), "$"))
+		return args[index-1].Value
+	}
+	parts := wherePattern.Split(strings.TrimSuffix(strings.SplitN(query, "returning", 2)[0], ";"), 2)
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var ids []int64
+	for id, job := range f.jobs {
+		matches := true
+		for _, condition := range conditionEquals.FindAllStringSubmatch(parts[1], -1) {
+			matches = matches && job[condition[1]] == arg("$"+condition[2])
+		}
+		for _, condition := range conditionIn.FindAllStringSubmatch(parts[1], -1) {
+			in := false
+			for _, placeholder := range strings.Split(condition[2], ",") {
+				in = in || job[condition[1]] == arg(placeholder)
+			}
+			matches = matches && in
+		}
+		if !matches {
+			continue
+		}
+		for _, set := range setPattern.FindAllStringSubmatch(parts[0], -1) {
+			if set[2] == "now()" {
+				job[set[1]] = time.Now()
+			} else {
+				job[set[1]] = arg(set[2])
+			}
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+func (f *fakeSyncJobs) Open(string) (driver.Conn, error) { return fakeSyncJobsConn{f}, nil }
+
+type fakeSyncJobsConn struct {
+	jobs *fakeSyncJobs
+}
+
+func (c fakeSyncJobsConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+func (c fakeSyncJobsConn) Close() error              { return nil }
+func (c fakeSyncJobsConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+func (c fakeSyncJobsConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	ids, err := c.jobs.update(query, args)
+	return driver.RowsAffected(len(ids)), err
+}
+
+func (c fakeSyncJobsConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	ids, err := c.jobs.update(query, args)
+	return &idRows{ids: ids}, err
+}
+
+type idRows struct {
+	ids []int64
+}
+
+func (r *idRows) Columns() []string { return []string{"id"} }
+func (r *idRows) Close() error      { return nil }
+func (r *idRows) Next(dest []driver.Value) error {
+	if len(r.ids) == 0 {
+		return io.EOF
+	}
+	dest[0], r.ids = r.ids[0], r.ids[1:]
+	return nil
+}
+
+type fakeSyncJobsConnector struct {
+	jobs *fakeSyncJobs
+}
+
+func (c fakeSyncJobsConnector) Connect(context.Context) (driver.Conn, error) { return c.jobs.Open("") }
+func (c fakeSyncJobsConnector) Driver() driver.Driver                        { return c.jobs }
+
+// job is a sync job of the fake database
+type job struct {
+	configId int64
+	status   string
+}
+
+func TestSyncJobTransitions(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		jobs      map[int64]job
+		run       func() ([]int64, error)
+		wantIds   []int64
+		want      map[int64]string
+		wantError map[int64]string
+	}{
+		{
+			name: "pending jobs of the configuration are started",
+			jobs: map[int64]job{1: {1, SyncJobPending}, 2: {1, SyncJobPending}, 3: {2, SyncJobPending}, 4: {1, SyncJobSucceeded}},
+			run:  func() ([]int64, error) { return StartPendingSyncJobs(ctx, 1) },
+			want: map[int64]string{1: SyncJobRunning, 2: SyncJobRunning, 3: SyncJobPending, 4: SyncJobSucceeded}, wantIds: []int64{1, 2},
+		},
+		{
+			name: "running jobs are not started again",
+			jobs: map[int64]job{1: {1, SyncJobRunning}},
+			run:  func() ([]int64, error) { return StartPendingSyncJobs(ctx, 1) },
+			want: map[int64]string{1: SyncJobRunning},
+		},
+		{
+			name: "running jobs of a configuration are requeued",
+			jobs: map[int64]job{1: {1, SyncJobRunning}, 2: {2, SyncJobRunning}, 3: {1, SyncJobFailed}},
+			run:  func() ([]int64, error) { return nil, RequeueRunningSyncJobs(ctx, 1) },
+			want: map[int64]string{1: SyncJobPending, 2: SyncJobRunning, 3: SyncJobFailed},
+		},
+		{
+			name: "pending jobs of a configuration are failed",
+			jobs: map[int64]job{1: {1, SyncJobPending}, 2: {1, SyncJobRunning}, 3: {2, SyncJobPending}},
+			run:  func() ([]int64, error) { return nil, FailPendingSyncJobs(ctx, 1, "configuration is disabled") },
+			want: map[int64]string{1: SyncJobFailed, 2: SyncJobRunning, 3: SyncJobPending}, wantError: map[int64]string{1: "configuration is disabled"},
+		},
+		{
+			name: "running jobs succeed",
+			jobs: map[int64]job{1: {1, SyncJobRunning}, 2: {1, SyncJobRunning}, 3: {1, SyncJobRunning}},
+			run:  func() ([]int64, error) { return nil, FinishSyncJobs(ctx, []int64{1, 2}, apiserver.SyncResult{}, nil) },
+			want: map[int64]string{1: SyncJobSucceeded, 2: SyncJobSucceeded, 3: SyncJobRunning},
+		},
+		{
+			name: "running jobs fail",
+			jobs: map[int64]job{1: {1, SyncJobRunning}},
+			run: func() ([]int64, error) {
+				return nil, FinishSyncJobs(ctx, []int64{1}, apiserver.SyncResult{}, errors.New("discovery failed"))
+			},
+			want: map[int64]string{1: SyncJobFailed}, wantError: map[int64]string{1: "discovery failed"},
+		},
+		{
+			name: "requeued jobs are not finished by the previous leader",
+			jobs: map[int64]job{1: {1, SyncJobPending}},
+			run:  func() ([]int64, error) { return nil, FinishSyncJobs(ctx, []int64{1}, apiserver.SyncResult{}, nil) },
+			want: map[int64]string{1: SyncJobPending},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs := useFakeSyncJobs(t, tt.jobs)
+			ids, err := tt.run()
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if len(ids) != len(tt.wantIds) || (len(ids) > 0 && !equalIds(ids, tt.wantIds)) {
+				t.Errorf("ids = %v, want %v", ids, tt.wantIds)
+			}
+			for id, want := range tt.want {
+				if got := jobs.jobs[id]["status"]; got != want {
+					t.Errorf("status of job %d = %v, want %v", id, got, want)
+				}
+			}
+			for id, want := range tt.wantError {
+				if got := jobs.jobs[id]["error"]; got != want {
+					t.Errorf("error of job %d = %v, want %v", id, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSyncJobLifecycle(t *testing.T) {
+	ctx := context.Background()
+	jobs := useFakeSyncJobs(t, map[int64]job{1: {1, SyncJobPending}})
+
+	// the leader starts the job and dies, the new leader requeues and runs it
+	if ids, err := StartPendingSyncJobs(ctx, 1); err != nil || !equalIds(ids, []int64{1}) {
+		t.Fatalf("StartPendingSyncJobs() = %v, %v", ids, err)
+	}
+	if jobs.jobs[1]["started_at"] == nil {
+		t.Errorf("started job has no start time")
+	}
+	if err := RequeueRunningSyncJobs(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if jobs.jobs[1]["status"] != SyncJobPending || jobs.jobs[1]["started_at"] != nil {
+		t.Errorf("requeued job = %v, want pending without start time", jobs.jobs[1])
+	}
+	if ids, err := StartPendingSyncJobs(ctx, 1); err != nil || !equalIds(ids, []int64{1}) {
+		t.Fatalf("StartPendingSyncJobs() after requeue = %v, %v", ids, err)
+	}
+	if err := FinishSyncJobs(ctx, []int64{1}, apiserver.SyncResult{DiscoveredObjects: 5}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if jobs.jobs[1]["status"] != SyncJobSucceeded || jobs.jobs[1]["finished_at"] == nil || jobs.jobs[1]["discovered_objects"] != int64(5) {
+		t.Errorf("finished job = %v, want succeeded with result", jobs.jobs[1])
+	}
+	if ids, err := StartPendingSyncJobs(ctx, 1); err != nil || len(ids) > 0 {
+		t.Errorf("StartPendingSyncJobs() of a finished job = %v, %v", ids, err)
+	}
+}
+
+// useFakeSyncJobs sets a fake database with the jobs as default database for the test
+func useFakeSyncJobs(t *testing.T, initial map[int64]job) *fakeSyncJobs {
+	jobs := &fakeSyncJobs{jobs: make(map[int64]map[string]driver.Value)}
+	for id, j := range initial {
+		jobs.jobs[id] = map[string]driver.Value{"id": id, "configuration_id": j.configId, "status": j.status}
+	}
+	db := sql.OpenDB(fakeSyncJobsConnector{jobs: jobs})
+	previous := boil.GetDB()
+	boil.SetDB(db)
+	t.Cleanup(func() {
+		boil.SetDB(previous)
+		_ = db.Close()
+	})
+	return jobs
+}
+
+func equalIds(a []int64, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+--  This file is part of the eliona project.
+--  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+--  ______ _ _
+-- |  ____| (_)
+-- | |__  | |_  ___  _ __   __ _
+-- |  __| | | |/ _ \| '_ \ / _` |
+-- | |____| | | (_) | | | | (_| |
+-- |______|_|_|\___/|_| |_|\__,_|
+--
+--  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+--  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+--  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+--  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+--  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+alter table signify.configuration add column if not exists excluded_uuids text[];
+alter table signify.configuration add column if not exists project_bindings json;
+alter table signify.configuration add column if not exists stale_thresholds json;
+alter table signify.configuration add column if not exists stale_occupancy_unknown boolean default false;
+alter table signify.asset add column if not exists attached boolean not null default false;
+alter table signify.asset add column if not exists asset_type text;
+alter table signify.asset add column if not exists name text;
+alter table signify.asset add column if not exists definition text;
+alter table signify.configuration add column if not exists alarm_templates json;
+alter table signify.configuration add column if not exists notification_recipients text[];
+alter table signify.configuration add column if not exists notification_digest_interval integer;
+
+create table if not exists signify.alarm_rule
+(
+    id               bigserial primary key,
+    configuration_id bigint  not null references signify.configuration(id) on delete cascade,
+    asset_id         integer not null,
+    template_name    text    not null,
+    alarm_rule_id    integer not null,
+    definition       text    not null,
+    unique (configuration_id, asset_id, template_name)
+);
+
+create table if not exists signify.event
+(
+    id               bigserial primary key,
+    configuration_id bigint,
+    type             text        not null,
+    message          text        not null,
+    created_at       timestamptz not null default now()
+);
+create index if not exists event_configuration_id_created_at_idx on signify.event (configuration_id, created_at);
+
+-- Mappings inserted twice by concurrently running instances are removed before the unique index prevents them
+delete from signify.asset duplicate
+    using signify.asset original
+    where duplicate.configuration_id = original.configuration_id
+      and duplicate.project_id = original.project_id
+      and duplicate.global_asset_id = original.global_asset_id
+      and duplicate.id > original.id;
+create unique index if not exists asset_configuration_id_project_id_global_asset_id_key
+    on signify.asset (configuration_id, project_id, global_asset_id);
+
+create table if not exists signify.sync_job
+(
+    id                 bigserial primary key,
+    configuration_id   bigint      not null references signify.configuration(id) on delete cascade,
+    status             text        not null default 'pending',
+    created_at         timestamptz not null default now(),
+    started_at         timestamptz,
+    finished_at        timestamptz,
+    discovered_objects integer,
+    created_assets     integer,
+    removed_assets     integer,
+    error              text
+);
+create index if not exists sync_job_configuration_id_status_idx on signify.sync_job (configuration_id, status);
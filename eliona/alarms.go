@@ -0,0 +1,89 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"fmt"
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-eliona/client"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"net/http"
+	"signify/apiserver"
+	"signify/metrics"
+	"time"
+)
+
+// AlarmRule returns the alarm rule defined by the template for an asset
+func AlarmRule(template apiserver.AlarmTemplate, assetId int32) api.AlarmRule {
+	subtype := api.SUBTYPE_INPUT
+	if template.Subtype != "" {
+		subtype = api.DataSubtype(template.Subtype)
+	}
+	priority := api.ALARM_PRIORITY_MEDIUM
+	if template.Priority != 0 {
+		priority = api.AlarmPriority(template.Priority)
+	}
+	message := make(map[string]any)
+	for language, text := range template.Message {
+		message[language] = text
+	}
+	return api.AlarmRule{
+		AssetId:             assetId,
+		Subtype:             subtype,
+		Attribute:           template.Attribute,
+		Enable:              common.Ptr(true),
+		Priority:            priority,
+		RequiresAcknowledge: common.Ptr(template.RequiresAcknowledge != nil && *template.RequiresAcknowledge),
+		Low:                 *api.NewNullableFloat64(template.Low),
+		High:                *api.NewNullableFloat64(template.High),
+		Equal:               *api.NewNullableFloat64(template.Equal),
+		Message:             message,
+		Tags:                []string{"Signify", template.Name},
+	}
+}
+
+// UpsertAlarmRule creates the alarm rule or updates it, if it has an id
+func UpsertAlarmRule(rule api.AlarmRule) (int32, error) {
+	start := time.Now()
+	var upserted *api.AlarmRule
+	var err error
+	if rule.Id.IsSet() && rule.Id.Get() != nil {
+		upserted, _, err = client.NewClient().AlarmRulesAPI.
+			PutAlarmRuleById(client.AuthenticationContext(), *rule.Id.Get()).AlarmRule(rule).Execute()
+	} else {
+		upserted, _, err = client.NewClient().AlarmRulesAPI.
+			PostAlarmRule(client.AuthenticationContext()).AlarmRule(rule).Execute()
+	}
+	metrics.ObserveElionaWrite("alarm_rule", time.Since(start), err)
+	if err != nil {
+		return 0, err
+	}
+	if upserted == nil || upserted.Id.Get() == nil {
+		return 0, fmt.Errorf("no id returned for alarm rule of asset %d", rule.AssetId)
+	}
+	return *upserted.Id.Get(), nil
+}
+
+// DeleteAlarmRule deletes an alarm rule in Eliona. Alarm rules which are already deleted are ignored.
+func DeleteAlarmRule(alarmRuleId int32) error {
+	start := time.Now()
+	resp, err := client.NewClient().AlarmRulesAPI.DeleteAlarmRuleById(client.AuthenticationContext(), alarmRuleId).Execute()
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		err = nil
+	}
+	metrics.ObserveElionaWrite("alarm_rule_delete", time.Since(start), err)
+	return err
+}
@@ -16,13 +16,15 @@
 package eliona
 
 import (
+	"context"
 	"fmt"
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
 	"github.com/eliona-smart-building-assistant/go-eliona/asset"
 	"github.com/eliona-smart-building-assistant/go-eliona/client"
-	"github.com/eliona-smart-building-assistant/go-eliona/utils"
 	"github.com/eliona-smart-building-assistant/go-utils/common"
-	"signify/apiserver"
+	"net/http"
+	"signify/metrics"
+	"time"
 )
 
 const (
@@ -39,7 +41,16 @@ type Asset interface {
 	Id() string
 }
 
-func UpsertAsset(projectId string, uniqueIdentifier string, parentId *int32, assetType string, name string) (*int32, error) {
+// AssetMetadata holds the optional fields of an asset
+type AssetMetadata struct {
+	FunctionalParentId *int32
+	Latitude           *float64
+	Longitude          *float64
+	Tags               []string
+}
+
+func UpsertAsset(projectId string, uniqueIdentifier string, parentId *int32, assetType string, name string, metadata AssetMetadata) (*int32, error) {
+	start := time.Now()
 	assetId, err := asset.UpsertAsset(api.Asset{
 		ProjectId:               projectId,
 		GlobalAssetIdentifier:   uniqueIdentifier,
@@ -47,16 +58,32 @@ func UpsertAsset(projectId string, uniqueIdentifier string, parentId *int32, ass
 		AssetType:               assetType,
 		Description:             *api.NewNullableString(common.Ptr(fmt.Sprintf("%s (%v)", name, uniqueIdentifier))),
 		ParentLocationalAssetId: *api.NewNullableInt32(parentId),
+		ParentFunctionalAssetId: *api.NewNullableInt32(metadata.FunctionalParentId),
+		Latitude:                *api.NewNullableFloat64(metadata.Latitude),
+		Longitude:               *api.NewNullableFloat64(metadata.Longitude),
+		Tags:                    metadata.Tags,
 		DeviceIds: []string{
 			uniqueIdentifier,
 		},
 	})
+	metrics.ObserveElionaWrite("asset", time.Since(start), err)
 	if err != nil {
 		return nil, err
 	}
 	return assetId, nil
 }
 
+// DeleteAsset deletes an asset in Eliona. Assets which are already deleted are ignored.
+func DeleteAsset(assetId int32) error {
+	start := time.Now()
+	resp, err := client.NewClient().AssetsAPI.DeleteAssetById(client.AuthenticationContext(), assetId).Execute()
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		err = nil
+	}
+	metrics.ObserveElionaWrite("asset_delete", time.Since(start), err)
+	return err
+}
+
 func getAssetById(assetId int32) (*api.Asset, error) {
 	apiAsset, _, err := client.NewClient().AssetsAPI.
 		GetAssetById(client.AuthenticationContext(), assetId).Execute()
@@ -66,29 +93,24 @@ func getAssetById(assetId int32) (*api.Asset, error) {
 	return apiAsset, nil
 }
 
-func AdheresToFilter(input interface{}, filter [][]apiserver.FilterRule) (bool, error) {
-	f := apiFilterToCommonFilter(filter)
-	fp, err := utils.StructToMap(input)
+// getAssetsByProject returns all assets of a project by their id
+func getAssetsByProject(projectId string) (map[int32]api.Asset, error) {
+	apiAssets, _, err := client.NewClient().AssetsAPI.
+		GetAssets(client.AuthenticationContext()).ProjectId(projectId).Execute()
 	if err != nil {
-		return false, fmt.Errorf("converting strict to map: %v", err)
+		return nil, err
 	}
-	adheres, err := common.Filter(f, fp)
-	if err != nil {
-		return false, err
+	assets := make(map[int32]api.Asset)
+	for _, apiAsset := range apiAssets {
+		if apiAsset.Id.IsSet() && apiAsset.Id.Get() != nil {
+			assets[*apiAsset.Id.Get()] = apiAsset
+		}
 	}
-	return adheres, nil
+	return assets, nil
 }
 
-func apiFilterToCommonFilter(input [][]apiserver.FilterRule) [][]common.FilterRule {
-	result := make([][]common.FilterRule, len(input))
-	for i := 0; i < len(input); i++ {
-		result[i] = make([]common.FilterRule, len(input[i]))
-		for j := 0; j < len(input[i]); j++ {
-			result[i][j] = common.FilterRule{
-				Parameter: input[i][j].Parameter,
-				Regex:     input[i][j].Regex,
-			}
-		}
-	}
-	return result
+// CheckApi checks if the Eliona API is reachable
+func CheckApi(ctx context.Context) error {
+	_, _, err := client.NewClient().VersionAPI.GetVersion(client.AuthenticationContextWrap(ctx)).Execute()
+	return err
 }
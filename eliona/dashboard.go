@@ -17,335 +17,346 @@ package eliona
 
 import (
 	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
 	"github.com/eliona-smart-building-assistant/go-utils/common"
 	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"gopkg.in/yaml.v3"
+	"io/fs"
+	"os"
+	"path"
 	"signify/appdb"
 	"signify/conf"
+	"signify/logging"
+	"sort"
 )
 
-func SignifyPeopleCountDashboard(projectId string) (api.Dashboard, error) {
-	dashboard := api.Dashboard{}
-	dashboard.Name = "Signify People Count"
-	dashboard.ProjectId = projectId
-	dashboard.Widgets = []api.Widget{}
+// builtinTemplates are the dashboard templates shipped with the app
+//
+//go:embed dashboards/*.yaml
+var builtinTemplates embed.FS
 
-	storeys, err := conf.GetAssets(context.Background(),
-		appdb.AssetWhere.Kind.EQ(string(conf.StoreyAssetKind)),
-		appdb.AssetWhere.ProjectID.EQ(projectId),
-	)
-	if err != nil {
-		return api.Dashboard{}, err
+const (
+	StoreyGroupLevel   = "storey"
+	BuildingGroupLevel = "building"
+)
+
+// DashboardTemplate declares the widgets of a dashboard
+type DashboardTemplate struct {
+	Name    string           `yaml:"name" json:"name"`
+	Widgets []WidgetTemplate `yaml:"widgets" json:"widgets"`
+}
+
+// WidgetTemplate declares a widget, which is created for each storey or building with matching spaces
+type WidgetTemplate struct {
+	WidgetType string            `yaml:"widgetType" json:"widgetType"`
+	GroupBy    string            `yaml:"groupBy" json:"groupBy"`
+	Details    map[string]any    `yaml:"details" json:"details"`
+	Elements   []ElementTemplate `yaml:"elements" json:"elements"`
+}
+
+// ElementTemplate declares which attribute of the spaces with the asset type is shown in a widget element
+type ElementTemplate struct {
+	Sequence  int32  `yaml:"sequence" json:"sequence"`
+	AssetType string `yaml:"assetType" json:"assetType"`
+	Attribute string `yaml:"attribute" json:"attribute"`
+	Subtype   string `yaml:"subtype" json:"subtype"`
+}
+
+// DashboardScope restricts a dashboard to the storeys of a site or building, identified either by its Interact
+// UUID or by its Eliona asset ID. An empty scope takes all storeys of the project.
+type DashboardScope struct {
+	SiteUUID     string
+	BuildingUUID string
+	AssetId      int32
+}
+
+// DashboardTemplates returns the built-in templates and the custom templates from the directory set in
+// DASHBOARD_TEMPLATES_DIR by name. Custom templates replace built-in templates with the same name.
+func DashboardTemplates() (map[string]DashboardTemplate, error) {
+	templates := make(map[string]DashboardTemplate)
+	if err := loadTemplates(builtinTemplates, "dashboards", templates); err != nil {
+		return nil, fmt.Errorf("loading built-in dashboard templates: %w", err)
 	}
-	for _, storey := range storeys {
+	if dir := common.Getenv("DASHBOARD_TEMPLATES_DIR", ""); dir != "" {
+		if err := loadTemplates(os.DirFS(dir), ".", templates); err != nil {
+			return nil, fmt.Errorf("loading dashboard templates from %s: %w", dir, err)
+		}
+	}
+	return templates, nil
+}
 
-		apiStorey, err := getAssetById(storey.AssetID.Int32)
+func loadTemplates(fsys fs.FS, dir string, templates map[string]DashboardTemplate) error {
+	var names []string
+	for _, pattern := range []string{"*.yaml", "*.yml", "*.json"} {
+		matches, err := fs.Glob(fsys, path.Join(dir, pattern))
 		if err != nil {
-			return api.Dashboard{}, err
+			return err
 		}
-
-		if apiStorey == nil {
+		names = append(names, matches...)
+	}
+	for _, name := range names {
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		var template DashboardTemplate
+		unmarshal := yaml.Unmarshal
+		if path.Ext(name) == ".json" {
+			unmarshal = json.Unmarshal
+		}
+		if err := unmarshal(content, &template); err != nil {
+			logging.Tag("dashboard").Error("Skipping dashboard template", "name", name, "error", err)
 			continue
 		}
-
-		widget := api.Widget{
-			WidgetTypeName: "GeneralDisplay",
-			AssetId:        apiStorey.Id,
-			Sequence:       nullableInt32(int32(storey.ID)),
-			Details: map[string]any{
-				"size":     4,
-				"timespan": 7,
-			},
-			Data: []api.WidgetData{},
+		if err := template.validate(); err != nil {
+			logging.Tag("dashboard").Error("Skipping dashboard template", "name", name, "error", err)
+			continue
 		}
+		templates[template.Name] = template
+	}
+	return nil
+}
 
-		spaces, err := conf.GetAssets(context.Background(),
-			appdb.AssetWhere.ParentUUID.EQ(null.StringFrom(storey.UUID)),
-			appdb.AssetWhere.Kind.EQ(string(conf.SpaceAssetKind)),
-			appdb.AssetWhere.ProjectID.EQ(projectId),
-		)
-		if err != nil {
-			return api.Dashboard{}, err
+func (t *DashboardTemplate) validate() error {
+	if t.Name == "" {
+		return errors.New("missing name")
+	}
+	for i := range t.Widgets {
+		widget := &t.Widgets[i]
+		if widget.WidgetType == "" {
+			return fmt.Errorf("widget %d: missing widget type", i+1)
 		}
-
-		for _, space := range spaces {
-
-			apiSpace, err := getAssetById(space.AssetID.Int32)
-			if err != nil {
-				return api.Dashboard{}, err
+		if widget.GroupBy == "" {
+			widget.GroupBy = StoreyGroupLevel
+		}
+		if widget.GroupBy != StoreyGroupLevel && widget.GroupBy != BuildingGroupLevel {
+			return fmt.Errorf("widget %d: invalid groupBy %s", i+1, widget.GroupBy)
+		}
+		for j := range widget.Elements {
+			element := &widget.Elements[j]
+			if element.AssetType == "" || element.Attribute == "" {
+				return fmt.Errorf("widget %d: element %d: missing asset type or attribute", i+1, j+1)
 			}
-
-			if apiSpace == nil || apiSpace.AssetType != PeopleCountAssetType {
-				continue
+			if element.Sequence == 0 {
+				element.Sequence = 1
 			}
-
-			widgetData := api.WidgetData{
-				ElementSequence: nullableInt32(1),
-				AssetId:         apiSpace.Id,
-				Data: map[string]interface{}{
-					"aggregatedDataField": nil,
-					"aggregatedDataType":  "heap",
-					"attribute":           "people_count",
-					"description":         apiSpace.Name.Get(),
-					"key":                 "",
-					"seq":                 nullableInt32(int32(space.ID)),
-					"subtype":             "input",
-				},
+			if element.Subtype == "" {
+				element.Subtype = string(api.SUBTYPE_INPUT)
 			}
-
-			widget.Data = append(widget.Data, widgetData)
-
-		}
-
-		// add station widget to dashboard
-		if len(widget.Data) > 0 {
-			dashboard.Widgets = append(dashboard.Widgets, widget)
 		}
 	}
-	return dashboard, nil
+	return nil
+}
+
+// dashboardGroup is a storey or building with the spaces below it
+type dashboardGroup struct {
+	asset  *appdb.Asset
+	spaces []*appdb.Asset
 }
 
-func SignifyOccupancyDashboard(projectId string) (api.Dashboard, error) {
+// BuildDashboard renders the template for the storeys of the project within the scope. The hierarchy, asset types and
+// names are taken from the asset mappings.
+func BuildDashboard(template DashboardTemplate, projectId string, scope DashboardScope) (api.Dashboard, error) {
 	dashboard := api.Dashboard{}
-	dashboard.Name = "Signify Occupancy"
+	dashboard.Name = template.Name
 	dashboard.ProjectId = projectId
 	dashboard.Widgets = []api.Widget{}
 
-	storeys, err := conf.GetAssets(context.Background(),
-		appdb.AssetWhere.Kind.EQ(string(conf.StoreyAssetKind)),
-		appdb.AssetWhere.ProjectID.EQ(projectId),
-	)
+	groups, err := dashboardGroups(projectId, scope)
 	if err != nil {
 		return api.Dashboard{}, err
 	}
-	for _, storey := range storeys {
-
-		apiStorey, err := getAssetById(storey.AssetID.Int32)
-		if err != nil {
-			return api.Dashboard{}, err
-		}
-
-		if apiStorey == nil {
-			continue
-		}
-
-		widget := api.Widget{
-			WidgetTypeName: "GeneralDisplay",
-			AssetId:        apiStorey.Id,
-			Sequence:       nullableInt32(int32(storey.ID)),
-			Details: map[string]any{
-				"size":     4,
-				"timespan": 7,
-			},
-			Data: []api.WidgetData{},
-		}
 
-		spaces, err := conf.GetAssets(context.Background(),
-			appdb.AssetWhere.ParentUUID.EQ(null.StringFrom(storey.UUID)),
-			appdb.AssetWhere.Kind.EQ(string(conf.SpaceAssetKind)),
-			appdb.AssetWhere.ProjectID.EQ(projectId),
-		)
-		if err != nil {
-			return api.Dashboard{}, err
-		}
-
-		for _, space := range spaces {
-
-			apiSpace, err := getAssetById(space.AssetID.Int32)
-			if err != nil {
-				return api.Dashboard{}, err
-			}
-
-			if apiSpace == nil || apiSpace.AssetType != OccupancyAssetType {
+	sequence := int32(0)
+	for _, level := range []string{BuildingGroupLevel, StoreyGroupLevel} {
+		for _, group := range groups[level] {
+			if !group.asset.AssetID.Valid {
 				continue
 			}
-
-			widgetData := api.WidgetData{
-				ElementSequence: nullableInt32(1),
-				AssetId:         apiSpace.Id,
-				Data: map[string]interface{}{
-					"aggregatedDataField": nil,
-					"aggregatedDataType":  "heap",
-					"attribute":           "occupancy",
-					"description":         apiSpace.Name.Get(),
-					"key":                 "",
-					"seq":                 nullableInt32(int32(space.ID)),
-					"subtype":             "input",
-				},
+			for _, widgetTemplate := range template.Widgets {
+				if widgetTemplate.GroupBy != level {
+					continue
+				}
+				widget := api.Widget{
+					WidgetTypeName: widgetTemplate.WidgetType,
+					AssetId:        nullableInt32(group.asset.AssetID.Int32),
+					Details:        widgetTemplate.Details,
+					Data:           []api.WidgetData{},
+				}
+				for _, element := range widgetTemplate.Elements {
+					for _, space := range group.spaces {
+						if !space.AssetID.Valid || space.AssetType.String != element.AssetType {
+							continue
+						}
+						widget.Data = append(widget.Data, api.WidgetData{
+							ElementSequence: nullableInt32(element.Sequence),
+							AssetId:         nullableInt32(space.AssetID.Int32),
+							Data: map[string]interface{}{
+								"aggregatedDataField": nil,
+								"aggregatedDataType":  "heap",
+								"attribute":           element.Attribute,
+								"description":         space.Name.String,
+								"key":                 "",
+								"seq":                 nullableInt32(int32(space.ID)),
+								"subtype":             element.Subtype,
+							},
+						})
+					}
+				}
+
+				// add widget to dashboard
+				if len(widget.Data) > 0 {
+					sequence++
+					widget.Sequence = nullableInt32(sequence)
+					dashboard.Widgets = append(dashboard.Widgets, widget)
+				}
 			}
-
-			widget.Data = append(widget.Data, widgetData)
-
-		}
-
-		// add station widget to dashboard
-		if len(widget.Data) > 0 {
-			dashboard.Widgets = append(dashboard.Widgets, widget)
 		}
 	}
 	return dashboard, nil
 }
 
-func SignifyTemperatureDashboard(projectId string) (api.Dashboard, error) {
-	dashboard := api.Dashboard{}
-	dashboard.Name = "Signify Temperature"
-	dashboard.ProjectId = projectId
-	dashboard.Widgets = []api.Widget{}
+// dashboardGroups returns the storeys and buildings within the scope with their spaces, ordered by mapping
+func dashboardGroups(projectId string, scope DashboardScope) (map[string][]dashboardGroup, error) {
+	storeys, err := scopedStoreys(projectId, scope)
+	if err != nil {
+		return nil, err
+	}
+	groups := make(map[string][]dashboardGroup)
+	if len(storeys) == 0 {
+		return groups, nil
+	}
+	sort.Slice(storeys, func(i, j int) bool { return storeys[i].ID < storeys[j].ID })
 
-	storeys, err := conf.GetAssets(context.Background(),
-		appdb.AssetWhere.Kind.EQ(string(conf.StoreyAssetKind)),
+	var storeyUUIDs, buildingUUIDs []string
+	for _, storey := range storeys {
+		storeyUUIDs = append(storeyUUIDs, storey.UUID)
+		buildingUUIDs = append(buildingUUIDs, storey.ParentUUID.String)
+	}
+	spaces, err := conf.GetAssets(context.Background(),
+		appdb.AssetWhere.ParentUUID.IN(storeyUUIDs),
+		appdb.AssetWhere.Kind.EQ(string(conf.SpaceAssetKind)),
 		appdb.AssetWhere.ProjectID.EQ(projectId),
+		qm.OrderBy(appdb.AssetColumns.ID),
 	)
 	if err != nil {
-		return api.Dashboard{}, err
+		return nil, err
+	}
+	if err := completeAssetTypesAndNames(projectId, spaces); err != nil {
+		return nil, err
+	}
+	spacesByStorey := make(map[string][]*appdb.Asset)
+	for _, space := range spaces {
+		spacesByStorey[space.ParentUUID.String] = append(spacesByStorey[space.ParentUUID.String], space)
+	}
+	buildings, err := conf.GetAssets(context.Background(),
+		appdb.AssetWhere.UUID.IN(buildingUUIDs),
+		appdb.AssetWhere.Kind.EQ(string(conf.BuildingAssetKind)),
+		appdb.AssetWhere.ProjectID.EQ(projectId),
+		qm.OrderBy(appdb.AssetColumns.ID),
+	)
+	if err != nil {
+		return nil, err
 	}
-	for _, storey := range storeys {
 
-		apiStorey, err := getAssetById(storey.AssetID.Int32)
-		if err != nil {
-			return api.Dashboard{}, err
+	for _, building := range buildings {
+		group := dashboardGroup{asset: building}
+		for _, storey := range storeys {
+			if storey.ParentUUID.String == building.UUID {
+				group.spaces = append(group.spaces, spacesByStorey[storey.UUID]...)
+			}
 		}
+		groups[BuildingGroupLevel] = append(groups[BuildingGroupLevel], group)
+	}
+	for _, storey := range storeys {
+		groups[StoreyGroupLevel] = append(groups[StoreyGroupLevel], dashboardGroup{asset: storey, spaces: spacesByStorey[storey.UUID]})
+	}
+	return groups, nil
+}
 
-		if apiStorey == nil {
+// completeAssetTypesAndNames reads the asset types and names missing in mappings inserted before they were kept
+// with the mappings. All missing values are read with a single request to Eliona.
+func completeAssetTypesAndNames(projectId string, mappings []*appdb.Asset) error {
+	var apiAssets map[int32]api.Asset
+	for _, mapping := range mappings {
+		if mapping.AssetType.Valid {
 			continue
 		}
-
-		widget := api.Widget{
-			WidgetTypeName: "GeneralDisplay",
-			AssetId:        apiStorey.Id,
-			Sequence:       nullableInt32(int32(storey.ID)),
-			Details: map[string]any{
-				"size":     4,
-				"timespan": 7,
-			},
-			Data: []api.WidgetData{},
-		}
-
-		spaces, err := conf.GetAssets(context.Background(),
-			appdb.AssetWhere.ParentUUID.EQ(null.StringFrom(storey.UUID)),
-			appdb.AssetWhere.Kind.EQ(string(conf.SpaceAssetKind)),
-			appdb.AssetWhere.ProjectID.EQ(projectId),
-		)
-		if err != nil {
-			return api.Dashboard{}, err
-		}
-
-		for _, space := range spaces {
-
-			apiSpace, err := getAssetById(space.AssetID.Int32)
+		if apiAssets == nil {
+			var err error
+			apiAssets, err = getAssetsByProject(projectId)
 			if err != nil {
-				return api.Dashboard{}, err
-			}
-
-			if apiSpace == nil || apiSpace.AssetType != TemperatureAssetType {
-				continue
-			}
-
-			widgetData := api.WidgetData{
-				ElementSequence: nullableInt32(1),
-				AssetId:         apiSpace.Id,
-				Data: map[string]interface{}{
-					"aggregatedDataField": nil,
-					"aggregatedDataType":  "heap",
-					"attribute":           "temperature",
-					"description":         apiSpace.Name.Get(),
-					"key":                 "",
-					"seq":                 nullableInt32(int32(space.ID)),
-					"subtype":             "input",
-				},
+				return err
 			}
-
-			widget.Data = append(widget.Data, widgetData)
-
 		}
-
-		// add station widget to dashboard
-		if len(widget.Data) > 0 {
-			dashboard.Widgets = append(dashboard.Widgets, widget)
+		if apiAsset, ok := apiAssets[mapping.AssetID.Int32]; ok {
+			mapping.AssetType = null.StringFrom(apiAsset.AssetType)
+			mapping.Name = null.StringFromPtr(apiAsset.Name.Get())
 		}
 	}
-	return dashboard, nil
+	return nil
 }
 
-func SignifyHumidityDashboard(projectId string) (api.Dashboard, error) {
-	dashboard := api.Dashboard{}
-	dashboard.Name = "Signify Humidity"
-	dashboard.ProjectId = projectId
-	dashboard.Widgets = []api.Widget{}
-
-	storeys, err := conf.GetAssets(context.Background(),
+// scopedStoreys returns the storeys of a project within the scope. If the site or building of the scope is not mapped
+// in the project, conf.ErrNotFound is returned.
+func scopedStoreys(projectId string, scope DashboardScope) ([]*appdb.Asset, error) {
+	mods := []qm.QueryMod{
 		appdb.AssetWhere.Kind.EQ(string(conf.StoreyAssetKind)),
 		appdb.AssetWhere.ProjectID.EQ(projectId),
-	)
-	if err != nil {
-		return api.Dashboard{}, err
 	}
-	for _, storey := range storeys {
 
-		apiStorey, err := getAssetById(storey.AssetID.Int32)
-		if err != nil {
-			return api.Dashboard{}, err
-		}
+	var scopeMods []qm.QueryMod
+	switch {
+	case scope.AssetId != 0:
+		scopeMods = append(scopeMods, appdb.AssetWhere.AssetID.EQ(null.Int32From(scope.AssetId)),
+			appdb.AssetWhere.Kind.IN([]string{string(conf.SiteAssetKind), string(conf.BuildingAssetKind)}))
+	case scope.BuildingUUID != "":
+		scopeMods = append(scopeMods, appdb.AssetWhere.UUID.EQ(scope.BuildingUUID),
+			appdb.AssetWhere.Kind.EQ(string(conf.BuildingAssetKind)))
+	case scope.SiteUUID != "":
+		scopeMods = append(scopeMods, appdb.AssetWhere.UUID.EQ(scope.SiteUUID),
+			appdb.AssetWhere.Kind.EQ(string(conf.SiteAssetKind)))
+	default:
+		return conf.GetAssets(context.Background(), mods...)
+	}
 
-		if apiStorey == nil {
-			continue
-		}
+	scoped, err := conf.GetAssets(context.Background(), append(scopeMods, appdb.AssetWhere.ProjectID.EQ(projectId))...)
+	if err != nil {
+		return nil, err
+	}
+	if len(scoped) == 0 {
+		return nil, conf.ErrNotFound
+	}
 
-		widget := api.Widget{
-			WidgetTypeName: "GeneralDisplay",
-			AssetId:        apiStorey.Id,
-			Sequence:       nullableInt32(int32(storey.ID)),
-			Details: map[string]any{
-				"size":     4,
-				"timespan": 7,
-			},
-			Data: []api.WidgetData{},
+	// storeys are below buildings, so for a site the buildings below it are taken
+	var buildingUUIDs []string
+	for _, asset := range scoped {
+		if asset.Kind == string(conf.BuildingAssetKind) {
+			buildingUUIDs = append(buildingUUIDs, asset.UUID)
+			continue
 		}
-
-		spaces, err := conf.GetAssets(context.Background(),
-			appdb.AssetWhere.ParentUUID.EQ(null.StringFrom(storey.UUID)),
-			appdb.AssetWhere.Kind.EQ(string(conf.SpaceAssetKind)),
+		buildings, err := conf.GetAssets(context.Background(),
+			appdb.AssetWhere.ParentUUID.EQ(null.StringFrom(asset.UUID)),
+			appdb.AssetWhere.Kind.EQ(string(conf.BuildingAssetKind)),
 			appdb.AssetWhere.ProjectID.EQ(projectId),
 		)
 		if err != nil {
-			return api.Dashboard{}, err
-		}
-
-		for _, space := range spaces {
-
-			apiSpace, err := getAssetById(space.AssetID.Int32)
-			if err != nil {
-				return api.Dashboard{}, err
-			}
-
-			if apiSpace == nil || apiSpace.AssetType != HumidityAssetType {
-				continue
-			}
-
-			widgetData := api.WidgetData{
-				ElementSequence: nullableInt32(1),
-				AssetId:         apiSpace.Id,
-				Data: map[string]interface{}{
-					"aggregatedDataField": nil,
-					"aggregatedDataType":  "heap",
-					"attribute":           "humidity",
-					"description":         apiSpace.Name.Get(),
-					"key":                 "",
-					"seq":                 nullableInt32(int32(space.ID)),
-					"subtype":             "input",
-				},
-			}
-
-			widget.Data = append(widget.Data, widgetData)
-
+			return nil, err
 		}
-
-		// add station widget to dashboard
-		if len(widget.Data) > 0 {
-			dashboard.Widgets = append(dashboard.Widgets, widget)
+		for _, building := range buildings {
+			buildingUUIDs = append(buildingUUIDs, building.UUID)
 		}
 	}
-	return dashboard, nil
+	if len(buildingUUIDs) == 0 {
+		return nil, nil
+	}
+	mods = append(mods, appdb.AssetWhere.ParentUUID.IN(buildingUUIDs))
+	return conf.GetAssets(context.Background(), mods...)
 }
 
 func nullableInt32(val int32) api.NullableInt32 {
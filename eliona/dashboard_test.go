@@ -0,0 +1,295 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"signify/appdb"
+	"signify/conf"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// fakeAssets is a database holding asset mappings, which understands the selects of conf.GetAssets
+type fakeAssets struct {
+	assets []*appdb.Asset
+}
+
+var (
+	conditionEquals = regexp.MustCompile(`"?(\w+)"?\s*=\s*\$(\d+)`)
+	conditionIn     = regexp.MustCompile(`"?(\w+)"?\s+IN\s+\(([^)]*)\)`)
+	wherePattern    = regexp.MustCompile(`(?i)\swhere\s`)
+	orderPattern    = regexp.MustCompile(`(?i)\sorder by\s`)
+)
+
+var assetColumns = []string{"id", "kind", "uuid", "parent_uuid", "configuration_id", "project_id", "global_asset_id",
+	"asset_id", "attached", "asset_type", "name", "definition"}
+
+func assetValues(asset *appdb.Asset) map[string]driver.Value {
+	values := map[string]driver.Value{
+		"id":               asset.ID,
+		"kind":             asset.Kind,
+		"uuid":             asset.UUID,
+		"configuration_id": asset.ConfigurationID,
+		"project_id":       asset.ProjectID,
+		"global_asset_id":  asset.GlobalAssetID,
+		"attached":         asset.Attached,
+	}
+	for column, value := range map[string]driver.Valuer{"parent_uuid": asset.ParentUUID, "asset_id": asset.AssetID,
+		"asset_type": asset.AssetType, "name": asset.Name, "definition": asset.Definition} {
+		values[column], _ = value.Value()
+	}
+	return values
+}
+
+// selectAssets returns the assets matching all conditions of a select ordered by id
+func (f *fakeAssets) selectAssets(query string, args []driver.NamedValue) ([][]driver.Value, error) {
+	if !strings.HasPrefix(strings.ToLower(strings.TrimSpace(query)), "select") {
+		return nil, errors.New("unexpected statement " + query)
+	}
+	arg := func(placeholder string) string {
+		index, _ := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(placeholder), "$"))
+		return fmt.Sprint(args[index-1].Value)
+	}
+	var where string
+	if parts := wherePattern.Split(query, 2); len(parts) == 2 {
+		where = orderPattern.Split(parts[1], 2)[0]
+	}
+
+	var rows [][]driver.Value
+	for _, asset := range f.assets {
+		values := assetValues(asset)
+		matches := true
+		for _, condition := range conditionEquals.FindAllStringSubmatch(where, -1) {
+			matches = matches && fmt.Sprint(values[condition[1]]) == arg("$"+condition[2])
+		}
+		for _, condition := range conditionIn.FindAllStringSubmatch(where, -1) {
+			in := false
+			for _, placeholder := range strings.Split(condition[2], ",") {
+				in = in || fmt.Sprint(values[condition[1]]) == arg(placeholder)
+			}
+			matches = matches && in
+		}
+		if !matches {
+			continue
+		}
+		row := make([]driver.Value, len(assetColumns))
+		for i, column := range assetColumns {
+			row[i] = values[column]
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i][0].(int64) < rows[j][0].(int64) })
+	return rows, nil
+}
+
+func (f *fakeAssets) Open(string) (driver.Conn, error) { return fakeAssetsConn{f}, nil }
+
+type fakeAssetsConn struct {
+	assets *fakeAssets
+}
+
+func (c fakeAssetsConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+func (c fakeAssetsConn) Close() error              { return nil }
+func (c fakeAssetsConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+func (c fakeAssetsConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := c.assets.selectAssets(query, args)
+	return &assetRows{rows: rows}, err
+}
+
+type assetRows struct {
+	rows [][]driver.Value
+}
+
+func (r *assetRows) Columns() []string { return assetColumns }
+func (r *assetRows) Close() error      { return nil }
+func (r *assetRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+type fakeAssetsConnector struct {
+	assets *fakeAssets
+}
+
+func (c fakeAssetsConnector) Connect(context.Context) (driver.Conn, error) { return c.assets.Open("") }
+func (c fakeAssetsConnector) Driver() driver.Driver                        { return c.assets }
+
+// useFakeAssets replaces the database with the asset mappings for the test
+func useFakeAssets(t *testing.T, assets []*appdb.Asset) {
+	db := sql.OpenDB(fakeAssetsConnector{assets: &fakeAssets{assets: assets}})
+	previous := boil.GetDB()
+	boil.SetDB(db)
+	t.Cleanup(func() {
+		boil.SetDB(previous)
+		_ = db.Close()
+	})
+}
+
+func mapping(id int64, kind conf.AssetKind, uuid string, parentUUID string, projectId string, assetId int32, assetType string) *appdb.Asset {
+	asset := &appdb.Asset{
+		ID:            id,
+		Kind:          string(kind),
+		UUID:          uuid,
+		ProjectID:     projectId,
+		GlobalAssetID: uuid,
+		AssetID:       null.Int32From(assetId),
+		AssetType:     null.StringFrom(assetType),
+		Name:          null.StringFrom(uuid),
+	}
+	if parentUUID != "" {
+		asset.ParentUUID = null.StringFrom(parentUUID)
+	}
+	return asset
+}
+
+// testMappings maps a site with two buildings in project 1 and one of the buildings in project 2 as well
+func testMappings() []*appdb.Asset {
+	return []*appdb.Asset{
+		mapping(1, conf.SiteAssetKind, "s1", "", "1", 101, "signify_site"),
+		mapping(2, conf.BuildingAssetKind, "b1", "s1", "1", 102, "signify_building"),
+		mapping(3, conf.BuildingAssetKind, "b2", "s1", "1", 103, "signify_building"),
+		mapping(4, conf.StoreyAssetKind, "f1", "b1", "1", 104, "signify_storey"),
+		mapping(5, conf.StoreyAssetKind, "f2", "b1", "1", 105, "signify_storey"),
+		mapping(6, conf.StoreyAssetKind, "f3", "b2", "1", 106, "signify_storey"),
+		mapping(7, conf.SpaceAssetKind, "r1", "f1", "1", 107, "signify_occupancy_space"),
+		mapping(8, conf.SpaceAssetKind, "r2", "f1", "1", 108, "signify_temperature_space"),
+		mapping(9, conf.SpaceAssetKind, "r3", "f2", "1", 109, "signify_occupancy_space"),
+		mapping(10, conf.SpaceAssetKind, "r4", "f3", "1", 110, "signify_temperature_space"),
+		mapping(11, conf.SiteAssetKind, "s2", "", "1", 111, "signify_site"),
+		mapping(12, conf.BuildingAssetKind, "b1", "", "2", 201, "signify_building"),
+		mapping(13, conf.StoreyAssetKind, "f1", "b1", "2", 202, "signify_storey"),
+	}
+}
+
+func TestScopedStoreys(t *testing.T) {
+	useFakeAssets(t, testMappings())
+	tests := []struct {
+		name    string
+		project string
+		scope   DashboardScope
+		want    []int64
+		wantErr error
+	}{
+		{"all storeys of the project", "1", DashboardScope{}, []int64{4, 5, 6}, nil},
+		{"storeys of another project", "2", DashboardScope{}, []int64{13}, nil},
+		{"storeys of a building", "1", DashboardScope{BuildingUUID: "b1"}, []int64{4, 5}, nil},
+		{"storeys of a site", "1", DashboardScope{SiteUUID: "s1"}, []int64{4, 5, 6}, nil},
+		{"storeys of a building by asset id", "1", DashboardScope{AssetId: 103}, []int64{6}, nil},
+		{"storeys of a site by asset id", "1", DashboardScope{AssetId: 101}, []int64{4, 5, 6}, nil},
+		{"site without buildings", "1", DashboardScope{SiteUUID: "s2"}, nil, nil},
+		{"building of another project", "2", DashboardScope{BuildingUUID: "b2"}, nil, conf.ErrNotFound},
+		{"asset id of a storey", "1", DashboardScope{AssetId: 104}, nil, conf.ErrNotFound},
+		{"asset id of another project", "2", DashboardScope{AssetId: 102}, nil, conf.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storeys, err := scopedStoreys(tt.project, tt.scope)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("scopedStoreys() error = %v, want %v", err, tt.wantErr)
+			}
+			var got []int64
+			for _, storey := range storeys {
+				got = append(got, storey.ID)
+			}
+			sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("scopedStoreys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// widgetSummary describes a widget by its asset and the assets of its data
+type widgetSummary struct {
+	widgetType string
+	sequence   int32
+	assetId    int32
+	dataAssets []int32
+}
+
+func TestBuildDashboard(t *testing.T) {
+	useFakeAssets(t, testMappings())
+	template := DashboardTemplate{
+		Name: "Test",
+		Widgets: []WidgetTemplate{
+			{WidgetType: "Occupancy", GroupBy: StoreyGroupLevel, Elements: []ElementTemplate{
+				{Sequence: 1, AssetType: "signify_occupancy_space", Attribute: "occupancy", Subtype: "input"},
+			}},
+			{WidgetType: "Temperature", GroupBy: BuildingGroupLevel, Elements: []ElementTemplate{
+				{Sequence: 1, AssetType: "signify_temperature_space", Attribute: "temperature", Subtype: "input"},
+			}},
+		},
+	}
+	tests := []struct {
+		name  string
+		scope DashboardScope
+		want  []widgetSummary
+	}{
+		{"project", DashboardScope{}, []widgetSummary{
+			{"Temperature", 1, 102, []int32{108}},
+			{"Temperature", 2, 103, []int32{110}},
+			{"Occupancy", 3, 104, []int32{107}},
+			{"Occupancy", 4, 105, []int32{109}},
+		}},
+		{"building", DashboardScope{BuildingUUID: "b2"}, []widgetSummary{
+			{"Temperature", 1, 103, []int32{110}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dashboard, err := BuildDashboard(template, "1", tt.scope)
+			if err != nil {
+				t.Fatalf("BuildDashboard() error = %v", err)
+			}
+			if dashboard.Name != "Test" || dashboard.ProjectId != "1" {
+				t.Errorf("BuildDashboard() = %s in project %s", dashboard.Name, dashboard.ProjectId)
+			}
+			var got []widgetSummary
+			for _, widget := range dashboard.Widgets {
+				summary := widgetSummary{widgetType: widget.WidgetTypeName, sequence: *widget.Sequence.Get(), assetId: *widget.AssetId.Get()}
+				for _, data := range widget.Data {
+					summary.dataAssets = append(summary.dataAssets, *data.AssetId.Get())
+					if data.Data["attribute"] == "" || data.Data["subtype"] != "input" {
+						t.Errorf("BuildDashboard() widget data = %v", data.Data)
+					}
+				}
+				got = append(got, summary)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("BuildDashboard() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
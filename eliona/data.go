@@ -4,17 +4,46 @@ import (
 	"fmt"
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
 	"github.com/eliona-smart-building-assistant/go-eliona/asset"
+	"signify/metrics"
+	"time"
 )
 
+// UpsertInputData writes the given attributes as input data of an asset
+func UpsertInputData(assetId int32, data map[string]any) error {
+	return upsertSubtypeData(assetId, api.SUBTYPE_INPUT, data)
+}
+
+// UpsertStatusData writes the given attributes as status data of an asset
+func UpsertStatusData(assetId int32, data map[string]any) error {
+	return upsertSubtypeData(assetId, api.SUBTYPE_STATUS, data)
+}
+
+func upsertSubtypeData(assetId int32, subtype api.DataSubtype, data map[string]any) error {
+	start := time.Now()
+	err := asset.UpsertData(api.Data{
+		AssetId: assetId,
+		Subtype: subtype,
+		Data:    data,
+	})
+	metrics.ObserveElionaWrite("data", time.Since(start), err)
+	if err != nil {
+		return fmt.Errorf("upserting %s data: %w", subtype, err)
+	}
+	return nil
+}
+
 func UpsertData(assetId int32, data any) error {
 	subtypes := asset.SplitBySubtype(data)
 	for subtype, data := range subtypes {
 		if subtype != "" {
-			if err := asset.UpsertData(api.Data{
+			start := time.Now()
+			err := asset.UpsertData(api.Data{
 				AssetId: assetId,
 				Subtype: subtype,
 				Data:    data,
-			}); err != nil {
+			})
+			metrics.ObserveElionaWrite("data", time.Since(start), err)
+			if err != nil {
 				return fmt.Errorf("upserting data for subtype %s: %w", subtype, err)
 			}
 		}
@@ -0,0 +1,173 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"fmt"
+	"regexp"
+	"signify/apiserver"
+	"strconv"
+	"sync"
+
+	"github.com/eliona-smart-building-assistant/go-eliona/utils"
+)
+
+const (
+	RegexFilterOperator              = "regex"
+	EqualFilterOperator              = "eq"
+	NotEqualFilterOperator           = "ne"
+	LessThanFilterOperator           = "lt"
+	LessThanOrEqualFilterOperator    = "lte"
+	GreaterThanFilterOperator        = "gt"
+	GreaterThanOrEqualFilterOperator = "gte"
+)
+
+// AdheresToFilter checks whether the input of the given object type adheres to the filter. Only the groups
+// with at least one rule for the object type are evaluated, and only with the rules for the object type.
+// If no group has a rule for the object type, the input adheres to the filter.
+func AdheresToFilter(input interface{}, objectType string, filter [][]apiserver.FilterRule) (bool, error) {
+	applicableFilter := applicableFilterRules(objectType, filter)
+	if len(applicableFilter) == 0 {
+		return true, nil
+	}
+	properties, err := utils.StructToMap(input)
+	if err != nil {
+		return false, fmt.Errorf("converting strict to map: %v", err)
+	}
+	for _, group := range applicableFilter {
+		matches, err := matchesAllRules(group, properties)
+		if err != nil {
+			return false, err
+		}
+		if matches {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// applicableFilterRules reduces the filter to the rules which apply to the object type, dropping groups without any
+func applicableFilterRules(objectType string, filter [][]apiserver.FilterRule) [][]apiserver.FilterRule {
+	var result [][]apiserver.FilterRule
+	for _, group := range filter {
+		var applicableGroup []apiserver.FilterRule
+		for _, rule := range group {
+			if rule.ObjectType == nil || *rule.ObjectType == objectType {
+				applicableGroup = append(applicableGroup, rule)
+			}
+		}
+		// an empty group matches everything, as before rules could be scoped
+		if len(applicableGroup) > 0 || len(group) == 0 {
+			result = append(result, applicableGroup)
+		}
+	}
+	return result
+}
+
+func matchesAllRules(rules []apiserver.FilterRule, properties map[string]string) (bool, error) {
+	for _, rule := range rules {
+		property, ok := properties[rule.Parameter]
+		if !ok {
+			if !matchesMissing(rule) {
+				return false, nil
+			}
+			continue
+		}
+		matches, err := matchesRule(rule, property)
+		if err != nil {
+			return false, fmt.Errorf("applying filter to property %s: %v", rule.Parameter, err)
+		}
+		if !matches {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// matchesMissing evaluates a rule for an object without the rule's property. A missing property is unequal to any
+// value, so only "ne" rules match, and negated rules match if the rule doesn't.
+func matchesMissing(rule apiserver.FilterRule) bool {
+	matches := rule.Operator != nil && *rule.Operator == NotEqualFilterOperator
+	if rule.Negate != nil && *rule.Negate {
+		return !matches
+	}
+	return matches
+}
+
+// matchesRule evaluates a single rule. Comparisons are numeric if both values are numbers, otherwise
+// equality is compared as string and ordering does not match. Negated rules match if the comparison doesn't.
+func matchesRule(rule apiserver.FilterRule, property string) (bool, error) {
+	operator := RegexFilterOperator
+	if rule.Operator != nil && *rule.Operator != "" {
+		operator = *rule.Operator
+	}
+	matches, err := matchesOperator(operator, rule, property)
+	if err != nil {
+		return false, err
+	}
+	if rule.Negate != nil && *rule.Negate {
+		return !matches, nil
+	}
+	return matches, nil
+}
+
+func matchesOperator(operator string, rule apiserver.FilterRule, property string) (bool, error) {
+	value := rule.Value
+	if operator == RegexFilterOperator {
+		if rule.Regex != "" {
+			value = rule.Regex
+		}
+		r, err := compileRegex(value)
+		if err != nil {
+			return false, fmt.Errorf("compiling rule regexp %v: %v", value, err)
+		}
+		return r.MatchString(property), nil
+	}
+
+	propertyNumber, propertyErr := strconv.ParseFloat(property, 64)
+	valueNumber, valueErr := strconv.ParseFloat(value, 64)
+	numeric := propertyErr == nil && valueErr == nil
+	switch operator {
+	case EqualFilterOperator:
+		return (numeric && propertyNumber == valueNumber) || property == value, nil
+	case NotEqualFilterOperator:
+		return !((numeric && propertyNumber == valueNumber) || property == value), nil
+	case LessThanFilterOperator:
+		return numeric && propertyNumber < valueNumber, nil
+	case LessThanOrEqualFilterOperator:
+		return numeric && propertyNumber <= valueNumber, nil
+	case GreaterThanFilterOperator:
+		return numeric && propertyNumber > valueNumber, nil
+	case GreaterThanOrEqualFilterOperator:
+		return numeric && propertyNumber >= valueNumber, nil
+	}
+	return false, fmt.Errorf("unknown filter operator %s", operator)
+}
+
+// compiledRegexes caches the compiled regexes of the rules, as a filter is applied to each object
+var compiledRegexes sync.Map
+
+func compileRegex(expr string) (*regexp.Regexp, error) {
+	if r, ok := compiledRegexes.Load(expr); ok {
+		return r.(*regexp.Regexp), nil
+	}
+	r, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	compiledRegexes.Store(expr, r)
+	return r, nil
+}
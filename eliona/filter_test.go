@@ -0,0 +1,167 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona_test
+
+import (
+	"signify/apiserver"
+	"signify/eliona"
+	"testing"
+)
+
+func rule(objectType string, parameter string, operator string, value string, negate bool) apiserver.FilterRule {
+	r := apiserver.FilterRule{Parameter: parameter, Value: value, Negate: &negate}
+	if objectType != "" {
+		r.ObjectType = &objectType
+	}
+	if operator != "" {
+		r.Operator = &operator
+	}
+	return r
+}
+
+type filterInput struct {
+	Name     string `eliona:"name,filterable"`
+	Capacity string `eliona:"capacity,filterable"`
+}
+
+func TestAdheresToFilter(t *testing.T) {
+	input := filterInput{Name: "Meeting Room 2", Capacity: "12"}
+	tests := []struct {
+		name       string
+		objectType string
+		filter     [][]apiserver.FilterRule
+		want       bool
+	}{
+		{"no filter", "space", nil, true},
+		{"empty group", "space", [][]apiserver.FilterRule{{}}, true},
+		{"regex matches", "space", [][]apiserver.FilterRule{{rule("", "name", "regex", "^Meeting", false)}}, true},
+		{"regex does not match", "space", [][]apiserver.FilterRule{{rule("", "name", "regex", "^Office", false)}}, false},
+		{"legacy regex field", "space", [][]apiserver.FilterRule{{{Parameter: "name", Regex: "Room \\d"}}}, true},
+		{"all rules of a group must match", "space", [][]apiserver.FilterRule{{
+			rule("", "name", "regex", "^Meeting", false),
+			rule("", "capacity", "regex", "^2", false),
+		}}, false},
+		{"any group must match", "space", [][]apiserver.FilterRule{
+			{rule("", "capacity", "regex", "^2", false)},
+			{rule("", "name", "regex", "^Meeting", false)},
+		}, true},
+		{"rule scoped to the object type", "space", [][]apiserver.FilterRule{{rule("space", "name", "regex", "^Office", false)}}, false},
+		{"rule scoped to another object type", "building", [][]apiserver.FilterRule{{rule("space", "name", "regex", "^Office", false)}}, true},
+		{"unscoped rule applies to all object types", "building", [][]apiserver.FilterRule{{rule("", "name", "regex", "^Office", false)}}, false},
+		{"only rules of the object type are evaluated", "space", [][]apiserver.FilterRule{{
+			rule("building", "name", "regex", "^Office", false),
+			rule("space", "name", "regex", "^Meeting", false),
+		}}, true},
+		{"groups without rules of the object type are dropped", "space", [][]apiserver.FilterRule{
+			{rule("building", "name", "regex", "^Meeting", false)},
+			{rule("space", "name", "regex", "^Office", false)},
+		}, false},
+		{"empty group still matches with scoped rules", "space", [][]apiserver.FilterRule{
+			{},
+			{rule("space", "name", "regex", "^Office", false)},
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := eliona.AdheresToFilter(input, tt.objectType, tt.filter)
+			if err != nil {
+				t.Fatalf("AdheresToFilter() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("AdheresToFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdheresToFilterOperators(t *testing.T) {
+	input := filterInput{Name: "Meeting Room 2", Capacity: "12"}
+	tests := []struct {
+		name string
+		rule apiserver.FilterRule
+		want bool
+	}{
+		{"default operator is regex", rule("", "name", "", "Room", false), true},
+		{"eq matches", rule("", "name", "eq", "Meeting Room 2", false), true},
+		{"eq does not match", rule("", "name", "eq", "Meeting", false), false},
+		{"eq compares numbers", rule("", "capacity", "eq", "12.0", false), true},
+		{"ne matches", rule("", "name", "ne", "Office", false), true},
+		{"ne does not match", rule("", "capacity", "ne", "12", false), false},
+		{"lt matches", rule("", "capacity", "lt", "13", false), true},
+		{"lt does not match equal", rule("", "capacity", "lt", "12", false), false},
+		{"lte matches equal", rule("", "capacity", "lte", "12", false), true},
+		{"gt compares numerically", rule("", "capacity", "gt", "9", false), true},
+		{"gt does not match", rule("", "capacity", "gt", "12", false), false},
+		{"gte matches equal", rule("", "capacity", "gte", "12", false), true},
+		{"ordering of strings does not match", rule("", "name", "gt", "A", false), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := eliona.AdheresToFilter(input, "space", [][]apiserver.FilterRule{{tt.rule}})
+			if err != nil {
+				t.Fatalf("AdheresToFilter() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("AdheresToFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdheresToFilterNegate(t *testing.T) {
+	input := filterInput{Name: "Meeting Room 2", Capacity: "12"}
+	tests := []struct {
+		name string
+		rule apiserver.FilterRule
+		want bool
+	}{
+		{"negated regex", rule("", "name", "regex", "^Office", true), true},
+		{"negated eq", rule("", "name", "eq", "Meeting Room 2", true), false},
+		{"negated lt", rule("", "capacity", "lt", "10", true), true},
+		{"missing property with eq", rule("", "area", "eq", "12", false), false},
+		{"missing property with ne", rule("", "area", "ne", "12", false), true},
+		{"missing property with negated eq", rule("", "area", "eq", "12", true), true},
+		{"missing property with negated ne", rule("", "area", "ne", "12", true), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := eliona.AdheresToFilter(input, "space", [][]apiserver.FilterRule{{tt.rule}})
+			if err != nil {
+				t.Fatalf("AdheresToFilter() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("AdheresToFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdheresToFilterErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter [][]apiserver.FilterRule
+	}{
+		{"invalid regex", [][]apiserver.FilterRule{{rule("", "name", "regex", "(", false)}}},
+		{"unknown operator", [][]apiserver.FilterRule{{rule("", "name", "like", "Room", false)}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := eliona.AdheresToFilter(filterInput{Name: "Room"}, "space", tt.filter); err == nil {
+				t.Errorf("AdheresToFilter() expected error")
+			}
+		})
+	}
+}
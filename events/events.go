@@ -0,0 +1,149 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"signify/conf"
+	"signify/logging"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+type EventType string
+
+const (
+	DiscoveryEventType           EventType = "discovery"
+	DiscoveryFailedEventType     EventType = "discovery_failed"
+	AssetCreatedEventType        EventType = "asset_created"
+	AssetUpdatedEventType        EventType = "asset_updated"
+	AssetRemovedEventType        EventType = "asset_removed"
+	SubscriptionStartedEventType EventType = "subscription_started"
+	SubscriptionStoppedEventType EventType = "subscription_stopped"
+	TokenFailedEventType         EventType = "token_failed"
+	DataWriteFailedEventType     EventType = "data_write_failed"
+)
+
+// defaultRetentionDays is used if EVENT_RETENTION_DAYS is not set
+const defaultRetentionDays = 30
+
+// insertEvent writes an event to the database. Tests replace it to record the events.
+var insertEvent = conf.InsertEvent
+
+// Record writes an event of a configuration to the event log. Failing to record is logged only, so the activity
+// itself is not affected.
+func Record(configId int64, eventType EventType, format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	if err := insertEvent(context.Background(), &configId, string(eventType), message); err != nil {
+		logging.Config("events", configId).Error("Error recording event", "event_type", eventType, "message", message, "error", err)
+	}
+}
+
+// failing holds the failure types currently failing for each configuration
+var failing = make(map[int64]map[EventType]bool)
+var failingMutex sync.Mutex
+
+// RecordFailure records a failure event only if the failure type doesn't already fail for the configuration, so a
+// failure repeating e.g. for every received message is recorded once per failure episode. The episode ends with
+// RecordSuccess.
+func RecordFailure(configId int64, eventType EventType, format string, args ...any) {
+	failingMutex.Lock()
+	if failing[configId] == nil {
+		failing[configId] = make(map[EventType]bool)
+	}
+	ongoing := failing[configId][eventType]
+	failing[configId][eventType] = true
+	failingMutex.Unlock()
+	if !ongoing {
+		Record(configId, eventType, format, args...)
+	}
+}
+
+// RecordSuccess ends the failure episode of a failure type for the configuration
+func RecordSuccess(configId int64, eventType EventType) {
+	failingMutex.Lock()
+	defer failingMutex.Unlock()
+	delete(failing[configId], eventType)
+}
+
+// running holds the activities currently running for each configuration, e.g. the subscriptions
+var running = make(map[int64]map[string]bool)
+var runningMutex sync.Mutex
+
+// RecordStarted records the start event of an activity only if the activity isn't already running for the
+// configuration, so an activity restarted e.g. with each resubscription is recorded once until it stops. The activity
+// stops with RecordStopped.
+func RecordStarted(configId int64, activity string, eventType EventType, format string, args ...any) {
+	runningMutex.Lock()
+	if running[configId] == nil {
+		running[configId] = make(map[string]bool)
+	}
+	wasRunning := running[configId][activity]
+	running[configId][activity] = true
+	runningMutex.Unlock()
+	if !wasRunning {
+		Record(configId, eventType, format, args...)
+	}
+}
+
+// RecordStopped records the stop event of an activity only if the activity is running for the configuration
+func RecordStopped(configId int64, activity string, eventType EventType, format string, args ...any) {
+	runningMutex.Lock()
+	wasRunning := running[configId][activity]
+	delete(running[configId], activity)
+	runningMutex.Unlock()
+	if wasRunning {
+		Record(configId, eventType, format, args...)
+	}
+}
+
+// Running returns the activities currently running for the configuration
+func Running(configId int64) []string {
+	runningMutex.Lock()
+	defer runningMutex.Unlock()
+	var activities []string
+	for activity := range running[configId] {
+		activities = append(activities, activity)
+	}
+	sort.Strings(activities)
+	return activities
+}
+
+// RemoveExpired deletes the events older than the retention set in EVENT_RETENTION_DAYS
+func RemoveExpired() {
+	retentionDays := defaultRetentionDays
+	if value := common.Getenv("EVENT_RETENTION_DAYS", ""); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil || days <= 0 {
+			logging.Tag("events").Warn("Invalid EVENT_RETENTION_DAYS, using default", "value", value, "days", defaultRetentionDays)
+		} else {
+			retentionDays = days
+		}
+	}
+	count, err := conf.DeleteEventsBefore(context.Background(), time.Now().AddDate(0, 0, -retentionDays))
+	if err != nil {
+		logging.Tag("events").Error("Error removing expired events", "error", err)
+		return
+	}
+	if count > 0 {
+		logging.Tag("events").Debug("Removed expired events", "count", count)
+	}
+}
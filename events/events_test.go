@@ -0,0 +1,118 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package events
+
+import (
+	"context"
+	"signify/conf"
+	"testing"
+)
+
+type recordedEvent struct {
+	configId  int64
+	eventType string
+	message   string
+}
+
+// recordEvents resets the state of the failures and activities and records the events instead of writing them
+func recordEvents(t *testing.T) *[]recordedEvent {
+	var recorded []recordedEvent
+	failing = make(map[int64]map[EventType]bool)
+	running = make(map[int64]map[string]bool)
+	insertEvent = func(ctx context.Context, configId *int64, eventType string, message string) error {
+		recorded = append(recorded, recordedEvent{configId: *configId, eventType: eventType, message: message})
+		return nil
+	}
+	t.Cleanup(func() {
+		insertEvent = conf.InsertEvent
+	})
+	return &recorded
+}
+
+func TestRecordFailure(t *testing.T) {
+	recorded := recordEvents(t)
+
+	RecordFailure(1, DataWriteFailedEventType, "Writing failed: %s", "timeout")
+	RecordFailure(1, DataWriteFailedEventType, "Writing failed: %s", "timeout")
+	if len(*recorded) != 1 {
+		t.Fatalf("RecordFailure() recorded %d events for an ongoing failure, want 1", len(*recorded))
+	}
+	if got := (*recorded)[0]; got.configId != 1 || got.eventType != string(DataWriteFailedEventType) || got.message != "Writing failed: timeout" {
+		t.Errorf("RecordFailure() recorded %+v", got)
+	}
+
+	RecordFailure(1, TokenFailedEventType, "Token failed")
+	RecordFailure(2, DataWriteFailedEventType, "Writing failed")
+	if len(*recorded) != 3 {
+		t.Fatalf("RecordFailure() recorded %d events, want failures of other types and configurations recorded", len(*recorded))
+	}
+
+	RecordSuccess(1, DataWriteFailedEventType)
+	RecordFailure(1, DataWriteFailedEventType, "Writing failed")
+	RecordFailure(1, TokenFailedEventType, "Token failed")
+	if len(*recorded) != 4 {
+		t.Fatalf("RecordFailure() recorded %d events, want only the failure after the success recorded again", len(*recorded))
+	}
+	if got := (*recorded)[3].eventType; got != string(DataWriteFailedEventType) {
+		t.Errorf("RecordFailure() recorded %s, want %s", got, DataWriteFailedEventType)
+	}
+}
+
+func TestRecordSuccessWithoutFailure(t *testing.T) {
+	recorded := recordEvents(t)
+
+	RecordSuccess(1, DataWriteFailedEventType)
+	RecordFailure(1, DataWriteFailedEventType, "Writing failed")
+	if len(*recorded) != 1 {
+		t.Fatalf("RecordFailure() recorded %d events, want 1", len(*recorded))
+	}
+}
+
+func TestRecordStartedAndStopped(t *testing.T) {
+	recorded := recordEvents(t)
+
+	RecordStopped(1, "occupancy b1", SubscriptionStoppedEventType, "Subscription stopped")
+	if len(*recorded) != 0 {
+		t.Fatalf("RecordStopped() recorded the stop of an activity not running")
+	}
+
+	RecordStarted(1, "occupancy b1", SubscriptionStartedEventType, "Subscription started")
+	RecordStarted(1, "occupancy b1", SubscriptionStartedEventType, "Subscription started")
+	RecordStarted(1, "humidity b1", SubscriptionStartedEventType, "Subscription started")
+	if len(*recorded) != 2 {
+		t.Fatalf("RecordStarted() recorded %d events, want one per activity", len(*recorded))
+	}
+	if got := Running(1); len(got) != 2 || got[0] != "humidity b1" || got[1] != "occupancy b1" {
+		t.Errorf("Running() = %v, want both activities", got)
+	}
+	if got := Running(2); len(got) != 0 {
+		t.Errorf("Running() = %v for another configuration, want none", got)
+	}
+
+	RecordStopped(1, "occupancy b1", SubscriptionStoppedEventType, "Subscription stopped")
+	RecordStopped(1, "occupancy b1", SubscriptionStoppedEventType, "Subscription stopped")
+	if len(*recorded) != 3 {
+		t.Fatalf("RecordStopped() recorded %d events, want the stop recorded once", len(*recorded))
+	}
+	if got := (*recorded)[2].eventType; got != string(SubscriptionStoppedEventType) {
+		t.Errorf("RecordStopped() recorded %s, want %s", got, SubscriptionStoppedEventType)
+	}
+
+	RecordStarted(1, "occupancy b1", SubscriptionStartedEventType, "Subscription started")
+	if len(*recorded) != 4 {
+		t.Fatalf("RecordStarted() didn't record the start after the stop")
+	}
+}
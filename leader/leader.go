@@ -0,0 +1,141 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package leader
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"hash/crc32"
+	"signify/logging"
+	"sync"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// lockNamespace is the first key of the advisory locks. It separates the locks of the app from other locks in the
+// database, the second key is the configuration id.
+var lockNamespace = int32(crc32.ChecksumIEEE([]byte("signify")))
+
+// leases holds the dedicated database connections holding the advisory lock of each configuration led by this
+// instance. The lock is bound to the connection, so it is released by the database as soon as the instance dies.
+var leases = make(map[int64]*sql.Conn)
+var leasesMutex sync.Mutex
+
+// Lead returns true if this instance leads the configuration, i.e. collects its assets and streams its data. If no
+// other instance leads the configuration, this instance takes over the leadership.
+func Lead(ctx context.Context, configId int64) (bool, error) {
+	leasesMutex.Lock()
+	defer leasesMutex.Unlock()
+
+	if conn, ok := leases[configId]; ok {
+		err := conn.PingContext(ctx)
+		if err == nil {
+			return true, nil
+		}
+		// without the connection the lock is released, maybe already taken over by another instance
+		discard(conn)
+		delete(leases, configId)
+		logging.Config("leader", configId).Warn("Lost leadership", "error", err)
+	}
+
+	db, ok := boil.GetContextDB().(*sql.DB)
+	if !ok {
+		return false, errors.New("database doesn't support dedicated connections")
+	}
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "select pg_try_advisory_lock($1, $2)", lockNamespace, int32(configId)).Scan(&locked); err != nil {
+		discard(conn)
+		return false, err
+	}
+	if !locked {
+		_ = conn.Close()
+		return false, nil
+	}
+	leases[configId] = conn
+	logging.Config("leader", configId).Info("Took over leadership")
+	return true, nil
+}
+
+// IsLeader returns true if this instance held the leadership of the configuration when last checked with Lead
+func IsLeader(configId int64) bool {
+	leasesMutex.Lock()
+	defer leasesMutex.Unlock()
+	_, ok := leases[configId]
+	return ok
+}
+
+// Leading returns the configurations this instance held the leadership of when last checked with Lead
+func Leading() []int64 {
+	leasesMutex.Lock()
+	defer leasesMutex.Unlock()
+	configIds := make([]int64, 0, len(leases))
+	for configId := range leases {
+		configIds = append(configIds, configId)
+	}
+	return configIds
+}
+
+// Release gives up the leadership of a configuration, so another instance can take over
+func Release(ctx context.Context, configId int64) {
+	leasesMutex.Lock()
+	defer leasesMutex.Unlock()
+	release(ctx, configId)
+}
+
+// ReleaseExcept gives up the leadership of all configurations except the given ones, e.g. of deleted configurations
+func ReleaseExcept(ctx context.Context, configIds []int64) {
+	leasesMutex.Lock()
+	defer leasesMutex.Unlock()
+	keep := make(map[int64]bool)
+	for _, configId := range configIds {
+		keep[configId] = true
+	}
+	for configId := range leases {
+		if !keep[configId] {
+			release(ctx, configId)
+		}
+	}
+}
+
+// release unlocks and returns the connection of a lease. Needs the leases mutex to be held.
+func release(ctx context.Context, configId int64) {
+	conn, ok := leases[configId]
+	if !ok {
+		return
+	}
+	delete(leases, configId)
+	if _, err := conn.ExecContext(ctx, "select pg_advisory_unlock($1, $2)", lockNamespace, int32(configId)); err != nil {
+		// a connection still holding the lock must not return to the pool
+		discard(conn)
+		return
+	}
+	_ = conn.Close()
+	logging.Config("leader", configId).Info("Released leadership")
+}
+
+// discard closes the connection instead of returning it to the pool
+func discard(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error {
+		return driver.ErrBadConn
+	})
+	_ = conn.Close()
+}
@@ -0,0 +1,264 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package leader
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// fakeDatabase holds advisory locks like PostgreSQL: a lock is held by a connection and released with it
+type fakeDatabase struct {
+	mutex sync.Mutex
+	locks map[[2]int64]*fakeConn
+}
+
+func (d *fakeDatabase) Open(string) (driver.Conn, error) {
+	return &fakeConn{database: d}, nil
+}
+
+func (d *fakeDatabase) holder(configId int64) *fakeConn {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.locks[[2]int64{int64(lockNamespace), configId}]
+}
+
+type fakeConn struct {
+	database *fakeDatabase
+	broken   bool
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+// Close releases the locks of the connection, as the database does when a session ends
+func (c *fakeConn) Close() error {
+	c.database.mutex.Lock()
+	defer c.database.mutex.Unlock()
+	for key, holder := range c.database.locks {
+		if holder == c {
+			delete(c.database.locks, key)
+		}
+	}
+	return nil
+}
+
+func (c *fakeConn) Ping(context.Context) error {
+	if c.broken {
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if c.broken {
+		return nil, driver.ErrBadConn
+	}
+	if !strings.Contains(query, "pg_try_advisory_lock") {
+		return nil, errors.New("unexpected query " + query)
+	}
+	c.database.mutex.Lock()
+	defer c.database.mutex.Unlock()
+	key := lockKey(args)
+	holder, locked := c.database.locks[key]
+	if !locked {
+		c.database.locks[key] = c
+	}
+	return &boolRows{value: !locked || holder == c}, nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if c.broken {
+		return nil, driver.ErrBadConn
+	}
+	if !strings.Contains(query, "pg_advisory_unlock") {
+		return nil, errors.New("unexpected statement " + query)
+	}
+	c.database.mutex.Lock()
+	defer c.database.mutex.Unlock()
+	key := lockKey(args)
+	if c.database.locks[key] == c {
+		delete(c.database.locks, key)
+	}
+	return driver.RowsAffected(0), nil
+}
+
+// lockKey returns the keys of an advisory lock function called with two integers
+func lockKey(args []driver.NamedValue) [2]int64 {
+	var key [2]int64
+	for i := range key {
+		switch value := args[i].Value.(type) {
+		case int32:
+			key[i] = int64(value)
+		case int64:
+			key[i] = value
+		}
+	}
+	return key
+}
+
+// lose simulates losing the connection: the database releases its locks and pings fail
+func (c *fakeConn) lose() {
+	c.broken = true
+	_ = c.Close()
+}
+
+type boolRows struct {
+	value bool
+	read  bool
+}
+
+func (r *boolRows) Columns() []string { return []string{"locked"} }
+func (r *boolRows) Close() error      { return nil }
+func (r *boolRows) Next(dest []driver.Value) error {
+	if r.read {
+		return io.EOF
+	}
+	r.read = true
+	dest[0] = r.value
+	return nil
+}
+
+type fakeConnector struct {
+	database *fakeDatabase
+}
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return c.database.Open("") }
+func (c fakeConnector) Driver() driver.Driver                        { return c.database }
+
+// useFakeDatabase sets a fake database as default database for the test and starts without leases
+func useFakeDatabase(t *testing.T) *fakeDatabase {
+	database := &fakeDatabase{locks: make(map[[2]int64]*fakeConn)}
+	db := sql.OpenDB(fakeConnector{database: database})
+	previous := boil.GetDB()
+	boil.SetDB(db)
+	leases = make(map[int64]*sql.Conn)
+	t.Cleanup(func() {
+		boil.SetDB(previous)
+		_ = db.Close()
+	})
+	return database
+}
+
+// otherInstance takes the lock of the configuration with its own connection, like another instance of the app
+func otherInstance(t *testing.T, database *fakeDatabase, configId int64) *fakeConn {
+	conn := &fakeConn{database: database}
+	rows, err := conn.QueryContext(context.Background(), "select pg_try_advisory_lock($1, $2)",
+		[]driver.NamedValue{{Value: lockNamespace}, {Value: int32(configId)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := make([]driver.Value, 1)
+	_ = rows.Next(value)
+	if !value[0].(bool) {
+		t.Fatalf("other instance couldn't take the lock of configuration %d", configId)
+	}
+	return conn
+}
+
+func lead(t *testing.T, configId int64) bool {
+	leading, err := Lead(context.Background(), configId)
+	if err != nil {
+		t.Fatalf("Lead() error = %v", err)
+	}
+	return leading
+}
+
+func TestLeadTakesOverFreeConfiguration(t *testing.T) {
+	useFakeDatabase(t)
+	if !lead(t, 1) {
+		t.Fatalf("Lead() = false for a free configuration")
+	}
+	if !lead(t, 1) {
+		t.Errorf("Lead() = false for a configuration already led")
+	}
+	if !IsLeader(1) {
+		t.Errorf("IsLeader() = false after taking over")
+	}
+	if got := Leading(); len(got) != 1 || got[0] != 1 {
+		t.Errorf("Leading() = %v, want [1]", got)
+	}
+}
+
+func TestLeadTakesOverWhenOtherInstanceIsGone(t *testing.T) {
+	database := useFakeDatabase(t)
+	other := otherInstance(t, database, 1)
+	if lead(t, 1) {
+		t.Fatalf("Lead() = true for a configuration led by another instance")
+	}
+	if IsLeader(1) {
+		t.Errorf("IsLeader() = true for a configuration led by another instance")
+	}
+
+	_ = other.Close()
+	if !lead(t, 1) {
+		t.Errorf("Lead() = false after the other instance is gone")
+	}
+}
+
+func TestLeadLosesLeaseOnPingFailure(t *testing.T) {
+	database := useFakeDatabase(t)
+	if !lead(t, 1) {
+		t.Fatalf("Lead() = false for a free configuration")
+	}
+	database.holder(1).lose()
+	other := otherInstance(t, database, 1)
+
+	if lead(t, 1) {
+		t.Errorf("Lead() = true after the lease was lost to another instance")
+	}
+	if IsLeader(1) {
+		t.Errorf("IsLeader() = true after the lease was lost")
+	}
+
+	_ = other.Close()
+	if !lead(t, 1) {
+		t.Errorf("Lead() = false after the other instance is gone")
+	}
+}
+
+func TestRelease(t *testing.T) {
+	database := useFakeDatabase(t)
+	for _, configId := range []int64{1, 2, 3} {
+		if !lead(t, configId) {
+			t.Fatalf("Lead() = false for free configuration %d", configId)
+		}
+	}
+
+	Release(context.Background(), 1)
+	ReleaseExcept(context.Background(), []int64{2})
+	if IsLeader(1) || IsLeader(3) || !IsLeader(2) {
+		t.Errorf("leading %v, want only 2", Leading())
+	}
+	for configId, wantHeld := range map[int64]bool{1: false, 2: true, 3: false} {
+		if held := database.holder(configId) != nil; held != wantHeld {
+			t.Errorf("lock of configuration %d held = %v, want %v", configId, held, wantHeld)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// textHandler writes structured logs with the tag based logger, so text logs keep one format
+type textHandler struct {
+	attrs  []slog.Attr
+	prefix string
+}
+
+func (h *textHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return log.Lev() >= tagLevel(level)
+}
+
+func (h *textHandler) Handle(_ context.Context, record slog.Record) error {
+	tag := "app"
+	var fields strings.Builder
+	appendAttr := func(attr slog.Attr) {
+		if attr.Key == TagKey {
+			tag = attr.Value.String()
+			return
+		}
+		fmt.Fprintf(&fields, " %s=%v", attr.Key, attr.Value)
+	}
+	for _, attr := range h.attrs {
+		appendAttr(attr)
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		attr.Key = h.prefix + attr.Key
+		appendAttr(attr)
+		return true
+	})
+	log.Printf(tagLevel(record.Level), tag, "%s%s", record.Message, fields.String())
+	return nil
+}
+
+func (h *textHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handler := &textHandler{prefix: h.prefix, attrs: append([]slog.Attr{}, h.attrs...)}
+	for _, attr := range attrs {
+		attr.Key = h.prefix + attr.Key
+		handler.attrs = append(handler.attrs, attr)
+	}
+	return handler
+}
+
+func (h *textHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &textHandler{prefix: h.prefix + name + ".", attrs: h.attrs}
+}
+
+// jsonWriter takes the output of the tag based logger and writes each line as JSON with the structured logger,
+// so logs of libraries using the tag based logger are JSON as well.
+type jsonWriter struct {
+	handler slog.Handler
+}
+
+// tagTimeLayout is the time format of the tag based logger
+const tagTimeLayout = "2006-01-02 15:04:05.000000"
+
+// Write converts a line of the tag logger to a record. The tag logger writes each message with a single call,
+// so messages spanning multiple lines are kept in one record.
+func (w *jsonWriter) Write(p []byte) (int, error) {
+	line := strings.TrimRight(string(p), "\n")
+	// a line consists of level, time, tag and message separated by tabs
+	parts := strings.SplitN(line, "\t", 4)
+	if len(parts) < 4 || strings.Contains(parts[0]+parts[1]+parts[2], "\n") {
+		parts = []string{log.InfoLevel.String(), "", "app", line}
+	}
+	timestamp, err := time.ParseInLocation(tagTimeLayout, parts[1], time.Local)
+	if err != nil {
+		timestamp = time.Now()
+	}
+	record := slog.NewRecord(timestamp, parseLevel(parts[0]), parts[3], 0)
+	record.AddAttrs(slog.String(TagKey, strings.ToLower(parts[2])))
+	if err := w.handler.Handle(context.Background(), record); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func parseLevel(name string) slog.Level {
+	for _, level := range []log.Level{log.FatalLevel, log.ErrorLevel, log.WarnLevel, log.InfoLevel, log.DebugLevel, log.TraceLevel} {
+		if level.String() == name {
+			return slogLevel(level)
+		}
+	}
+	return slog.LevelInfo
+}
@@ -0,0 +1,135 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// Additional levels of the app's log levels, which slog doesn't define
+const (
+	LevelTrace = slog.LevelDebug - 4
+	LevelFatal = slog.LevelError + 4
+)
+
+// Keys of the context fields
+const (
+	TagKey              = "tag"
+	ConfigIdKey         = "config_id"
+	ProjectIdKey        = "project_id"
+	BuildingUUIDKey     = "building_uuid"
+	SubscriptionTypeKey = "subscription_type"
+)
+
+// Init sets up the default structured logger. With LOG_FORMAT set to json, all logs are written as JSON lines,
+// including the logs written with the tag based logger. Otherwise, the logs keep the tab separated text format
+// and the context fields are appended as key=value pairs. The minimum level is taken from LOG_LEVEL.
+func Init() {
+	if strings.EqualFold(common.Getenv("LOG_FORMAT", "text"), "json") {
+		handler := slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+			Level:       slogLevel(log.Lev()),
+			ReplaceAttr: replaceLevel,
+		})
+		slog.SetDefault(slog.New(handler))
+		log.SetOutput(&jsonWriter{handler: handler})
+	} else {
+		slog.SetDefault(slog.New(&textHandler{}))
+	}
+}
+
+// Tag returns a logger with the given tag, like the tags of the tag based logger
+func Tag(tag string) *slog.Logger {
+	return slog.Default().With(TagKey, tag)
+}
+
+// Config returns a tagged logger with the id of a configuration as field
+func Config(tag string, configId int64) *slog.Logger {
+	return Tag(tag).With(ConfigIdKey, configId)
+}
+
+// ProjectId returns the field for the id of an Eliona project
+func ProjectId(projectId string) slog.Attr {
+	return slog.String(ProjectIdKey, projectId)
+}
+
+// BuildingUUID returns the field for the UUID of an Interact building
+func BuildingUUID(buildingUUID string) slog.Attr {
+	return slog.String(BuildingUUIDKey, buildingUUID)
+}
+
+// SubscriptionType returns the field for the type of subscription
+func SubscriptionType(subscriptionType string) slog.Attr {
+	return slog.String(SubscriptionTypeKey, subscriptionType)
+}
+
+// Fatal logs a message with the fatal level and exits
+func Fatal(logger *slog.Logger, msg string, args ...any) {
+	logger.Log(context.Background(), LevelFatal, msg, args...)
+	os.Exit(1)
+}
+
+// slogLevel converts the level of the tag based logger to a slog level
+func slogLevel(level log.Level) slog.Level {
+	switch level {
+	case log.TraceLevel:
+		return LevelTrace
+	case log.DebugLevel:
+		return slog.LevelDebug
+	case log.InfoLevel:
+		return slog.LevelInfo
+	case log.WarnLevel:
+		return slog.LevelWarn
+	case log.ErrorLevel:
+		return slog.LevelError
+	default:
+		return LevelFatal
+	}
+}
+
+// tagLevel converts a slog level to the level of the tag based logger
+func tagLevel(level slog.Level) log.Level {
+	switch {
+	case level <= LevelTrace:
+		return log.TraceLevel
+	case level <= slog.LevelDebug:
+		return log.DebugLevel
+	case level <= slog.LevelInfo:
+		return log.InfoLevel
+	case level <= slog.LevelWarn:
+		return log.WarnLevel
+	case level <= slog.LevelError:
+		return log.ErrorLevel
+	default:
+		return log.FatalLevel
+	}
+}
+
+// replaceLevel names the additional levels in the output
+func replaceLevel(groups []string, attr slog.Attr) slog.Attr {
+	if attr.Key != slog.LevelKey || len(groups) > 0 {
+		return attr
+	}
+	if level, ok := attr.Value.Any().(slog.Level); ok {
+		attr.Value = slog.StringValue(tagLevel(level).String())
+	}
+	return attr
+}
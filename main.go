@@ -16,6 +16,9 @@
 package main
 
 import (
+	"signify/analytics"
+	"signify/events"
+	"signify/logging"
 	"time"
 
 	"github.com/eliona-smart-building-assistant/go-eliona/app"
@@ -28,7 +31,8 @@ import (
 // The main function starts the app by starting all services necessary for this app and waits
 // until all services are finished.
 func main() {
-	log.Info("main", "Starting the app.")
+	logging.Init()
+	logging.Tag("main").Info("Starting the app")
 
 	// Set default database to use boil.*G functions.
 	database := db.Database(app.AppName())
@@ -47,8 +51,13 @@ func main() {
 	// Starting the service to collect the data for this app.
 	common.WaitForWithOs(
 		common.Loop(collectAssets, time.Second),
+		common.Loop(analytics.WriteUtilisation, time.Minute),
+		common.Loop(checkStaleSpaces, 10*time.Second),
+		common.Loop(runSyncJobs, time.Second),
+		common.Loop(events.RemoveExpired, time.Hour),
+		common.Loop(removeExpiredSyncJobs, time.Hour),
 		listenApi,
 	)
 
-	log.Info("main", "Terminate the app.")
+	logging.Tag("main").Info("Terminate the app")
 }
@@ -0,0 +1,160 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "signify"
+
+var (
+	interactRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "interact_requests_total",
+		Help:      "Number of requests sent to the Interact REST API by endpoint and response status.",
+	}, []string{"endpoint", "status"})
+
+	interactRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "interact_request_duration_seconds",
+		Help:      "Duration of requests sent to the Interact REST API by endpoint.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"endpoint"})
+
+	tokenRefreshes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "token_refreshes_total",
+		Help:      "Number of bearer token requests by configuration and result.",
+	}, []string{"config_id", "result"})
+
+	discoveryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "discovery_duration_seconds",
+		Help:      "Duration of discovering sites, buildings, storeys and spaces by configuration.",
+		Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600},
+	}, []string{"config_id"})
+
+	websocketConnects = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "websocket_connects_total",
+		Help:      "Number of websocket connections opened by configuration and subscription type.",
+	}, []string{"config_id", "subscription_type"})
+
+	websocketDisconnects = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "websocket_disconnects_total",
+		Help:      "Number of websocket connections closed by configuration and subscription type.",
+	}, []string{"config_id", "subscription_type"})
+
+	messagesReceived = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_received_total",
+		Help:      "Number of messages received from websocket subscriptions by configuration and subscription type.",
+	}, []string{"config_id", "subscription_type"})
+
+	elionaWriteDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "eliona_write_duration_seconds",
+		Help:      "Duration of writes to the Eliona API by operation.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	elionaWriteErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "eliona_write_errors_total",
+		Help:      "Number of failed writes to the Eliona API by operation.",
+	}, []string{"operation"})
+
+	mappedAssets = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "mapped_assets",
+		Help:      "Number of Interact objects mapped to Eliona assets by configuration and kind.",
+	}, []string{"config_id", "kind"})
+)
+
+// Handler returns the HTTP handler exposing all metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveInteractRequest records a finished request to the Interact REST API. A status of 0 means
+// that no response was received.
+func ObserveInteractRequest(endpoint string, status int, duration time.Duration) {
+	statusLabel := strconv.Itoa(status)
+	if status == 0 {
+		statusLabel = "error"
+	}
+	interactRequests.WithLabelValues(endpoint, statusLabel).Inc()
+	interactRequestDuration.WithLabelValues(endpoint).Observe(duration.Seconds())
+}
+
+// ObserveTokenRefresh records a request for a new bearer token.
+func ObserveTokenRefresh(configId int64, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	tokenRefreshes.WithLabelValues(configLabel(configId), result).Inc()
+}
+
+// ObserveDiscovery records the duration of a discovery run.
+func ObserveDiscovery(configId int64, duration time.Duration) {
+	discoveryDuration.WithLabelValues(configLabel(configId)).Observe(duration.Seconds())
+}
+
+// WebsocketConnected records an opened websocket connection.
+func WebsocketConnected(configId int64, subscriptionType string) {
+	websocketConnects.WithLabelValues(configLabel(configId), subscriptionType).Inc()
+}
+
+// WebsocketDisconnected records a closed websocket connection.
+func WebsocketDisconnected(configId int64, subscriptionType string) {
+	websocketDisconnects.WithLabelValues(configLabel(configId), subscriptionType).Inc()
+}
+
+// MessageReceived records a message received from a websocket subscription.
+func MessageReceived(configId int64, subscriptionType string) {
+	messagesReceived.WithLabelValues(configLabel(configId), subscriptionType).Inc()
+}
+
+// ObserveElionaWrite records a finished write to the Eliona API.
+func ObserveElionaWrite(operation string, duration time.Duration, err error) {
+	elionaWriteDuration.WithLabelValues(operation).Observe(duration.Seconds())
+	if err != nil {
+		elionaWriteErrors.WithLabelValues(operation).Inc()
+	}
+}
+
+// SetMappedAssets sets the number of mapped assets for a configuration and asset kind.
+func SetMappedAssets(configId int64, kind string, count int64) {
+	mappedAssets.WithLabelValues(configLabel(configId), kind).Set(float64(count))
+}
+
+// ResetMappedAssets removes all mapped assets counts of a configuration.
+func ResetMappedAssets(configId int64) {
+	mappedAssets.DeletePartialMatch(prometheus.Labels{"config_id": configLabel(configId)})
+}
+
+func configLabel(configId int64) string {
+	return strconv.FormatInt(configId, 10)
+}
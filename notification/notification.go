@@ -0,0 +1,304 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package notification
+
+import (
+	"fmt"
+	"signify/apiserver"
+	"signify/conf"
+	"signify/eliona"
+	"signify/logging"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxFailures limits the failures listed in one notification
+const maxFailures = 10
+
+type FailureType string
+
+const (
+	DiscoveryFailure     FailureType = "discovery"
+	AssetCreationFailure FailureType = "asset_creation"
+	SubscriptionFailure  FailureType = "subscription"
+)
+
+// failure is a failure of a configuration. The subject tells what failed, e.g. the project the assets couldn't be
+// created in, and the detail is the error.
+type failure struct {
+	failureType FailureType
+	subject     string
+	detail      string
+}
+
+type failureKey struct {
+	failureType FailureType
+	subject     string
+}
+
+// summary collects the changes of a project and the failures of a configuration until they are notified
+type summary struct {
+	created   map[conf.AssetKind]int
+	updated   map[conf.AssetKind]int
+	removed   map[conf.AssetKind]int
+	buildings []string
+	failures  []failure
+}
+
+type summaryKey struct {
+	configId  int64
+	projectId string
+}
+
+var summaries = make(map[summaryKey]*summary)
+var lastSent = make(map[int64]time.Time)
+
+// failing holds the failures currently failing for each configuration, which were already notified
+var failing = make(map[int64]map[failureKey]bool)
+var mutex sync.Mutex
+
+// sendNotification sends a notification to Eliona. Tests replace it to record the notifications.
+var sendNotification = eliona.SendNotification
+
+func pendingSummary(configId int64, projectId string) *summary {
+	key := summaryKey{configId: configId, projectId: projectId}
+	s, ok := summaries[key]
+	if !ok {
+		s = &summary{
+			created: make(map[conf.AssetKind]int),
+			updated: make(map[conf.AssetKind]int),
+			removed: make(map[conf.AssetKind]int),
+		}
+		summaries[key] = s
+	}
+	return s
+}
+
+func (s *summary) addBuilding(building string) {
+	if building != "" && !slices.Contains(s.buildings, building) {
+		s.buildings = append(s.buildings, building)
+	}
+}
+
+// Created records an asset created in a project. The building is the name of the building the asset belongs to, if any.
+func Created(configId int64, projectId string, kind conf.AssetKind, building string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	s := pendingSummary(configId, projectId)
+	s.created[kind]++
+	s.addBuilding(building)
+}
+
+// Updated records an asset updated in a project
+func Updated(configId int64, projectId string, kind conf.AssetKind, building string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	s := pendingSummary(configId, projectId)
+	s.updated[kind]++
+	s.addBuilding(building)
+}
+
+// Removed records an asset removed from a project
+func Removed(configId int64, projectId string, kind conf.AssetKind, building string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	s := pendingSummary(configId, projectId)
+	s.removed[kind]++
+	s.addBuilding(building)
+}
+
+// Failed records a failure of a configuration, which is not related to a single project. As with
+// events.RecordFailure, a failure repeating with each synchronization is notified once per failure episode. The
+// episode ends with Recovered.
+func Failed(configId int64, failureType FailureType, subject string, err error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	key := failureKey{failureType: failureType, subject: subject}
+	if failing[configId] == nil {
+		failing[configId] = make(map[failureKey]bool)
+	}
+	if failing[configId][key] {
+		return
+	}
+	failing[configId][key] = true
+	s := pendingSummary(configId, "")
+	if len(s.failures) < maxFailures {
+		s.failures = append(s.failures, failure{failureType: failureType, subject: subject, detail: err.Error()})
+	}
+}
+
+// SubscriptionSubject is the subject of a failed subscription of a building
+func SubscriptionSubject(subscriptionType string, buildingUUID string) string {
+	return fmt.Sprintf("%s %s", subscriptionType, buildingUUID)
+}
+
+// Recovered ends the failure episode of a failure for the configuration
+func Recovered(configId int64, failureType FailureType, subject string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	delete(failing[configId], failureKey{failureType: failureType, subject: subject})
+}
+
+// Send notifies the recipients of a configuration about the recorded changes and failures, one notification per
+// project. With a digest interval, the notifications are sent at most once per interval and summarize all
+// synchronizations since the last notification.
+func Send(config apiserver.Configuration) {
+	mutex.Lock()
+	if interval := conf.NotificationDigestInterval(config); interval > 0 && time.Since(lastSent[*config.Id]) < interval {
+		mutex.Unlock()
+		return
+	}
+	pending := make(map[string]*summary)
+	for key, s := range summaries {
+		if key.configId == *config.Id {
+			pending[key.projectId] = s
+			delete(summaries, key)
+		}
+	}
+	lastSent[*config.Id] = time.Now()
+	mutex.Unlock()
+
+	recipients := conf.NotificationRecipients(config)
+	if len(recipients) == 0 {
+		if len(pending) > 0 {
+			logging.Config("notification", *config.Id).Debug("No recipients for notifications")
+		}
+		return
+	}
+	for projectId, s := range pending {
+		if s.empty() {
+			continue
+		}
+		var project *string
+		if projectId != "" {
+			project = &projectId
+		}
+		for _, recipient := range recipients {
+			if err := sendNotification(recipient, project, s.message("de"), s.message("en")); err != nil {
+				logging.Config("notification", *config.Id).Error("Error notifying user", "recipient", recipient, logging.ProjectId(projectId), "error", err)
+			}
+		}
+	}
+}
+
+func (s *summary) empty() bool {
+	return len(s.created) == 0 && len(s.updated) == 0 && len(s.removed) == 0 && len(s.failures) == 0
+}
+
+var kindNames = map[string]map[conf.AssetKind]string{
+	"de": {
+		conf.RootAssetKind:            "Wurzel-Assets",
+		conf.SiteAssetKind:            "Standorte",
+		conf.BuildingAssetKind:        "Gebäude",
+		conf.StoreyAssetKind:          "Stockwerke",
+		conf.SpaceAssetKind:           "Räume",
+		conf.FunctionalGroupAssetKind: "Funktionsgruppen",
+	},
+	"en": {
+		conf.RootAssetKind:            "root assets",
+		conf.SiteAssetKind:            "sites",
+		conf.BuildingAssetKind:        "buildings",
+		conf.StoreyAssetKind:          "storeys",
+		conf.SpaceAssetKind:           "spaces",
+		conf.FunctionalGroupAssetKind: "functional groups",
+	},
+}
+
+var texts = map[string]map[string]string{
+	"de": {
+		"intro":     "Signify App hat Assets synchronisiert.",
+		"created":   "Angelegt",
+		"updated":   "Aktualisiert",
+		"removed":   "Entfernt",
+		"buildings": "Betroffene Gebäude",
+		"failures":  "Fehler",
+		"failed":    "Signify App meldet Fehler.",
+
+		string(DiscoveryFailure):     "Erkennung der Objekte fehlgeschlagen",
+		string(AssetCreationFailure): "Anlegen der Assets fehlgeschlagen",
+		string(SubscriptionFailure):  "Abonnieren der Daten fehlgeschlagen",
+	},
+	"en": {
+		"intro":     "Signify app synchronized assets.",
+		"created":   "Created",
+		"updated":   "Updated",
+		"removed":   "Removed",
+		"buildings": "Affected buildings",
+		"failures":  "Failures",
+		"failed":    "Signify app reports failures.",
+
+		string(DiscoveryFailure):     "Discovery of the objects failed",
+		string(AssetCreationFailure): "Creating the assets failed",
+		string(SubscriptionFailure):  "Subscribing the data failed",
+	},
+}
+
+// message returns the text of the notification in the language
+func (s *summary) message(language string) string {
+	var parts []string
+	if len(s.created) > 0 || len(s.updated) > 0 || len(s.removed) > 0 {
+		parts = append(parts, texts[language]["intro"])
+	} else {
+		parts = append(parts, texts[language]["failed"])
+	}
+	for _, counts := range []struct {
+		text   string
+		counts map[conf.AssetKind]int
+	}{
+		{"created", s.created},
+		{"updated", s.updated},
+		{"removed", s.removed},
+	} {
+		if len(counts.counts) > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %s.", texts[language][counts.text], formatCounts(counts.counts, language)))
+		}
+	}
+	if len(s.buildings) > 0 {
+		buildings := slices.Clone(s.buildings)
+		sort.Strings(buildings)
+		parts = append(parts, fmt.Sprintf("%s: %s.", texts[language]["buildings"], strings.Join(buildings, ", ")))
+	}
+	if len(s.failures) > 0 {
+		var failures []string
+		for _, f := range s.failures {
+			failures = append(failures, f.format(language))
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", texts[language]["failures"], strings.Join(failures, "; ")))
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatCounts lists the counts from the top of the hierarchy down to the spaces
+func formatCounts(counts map[conf.AssetKind]int, language string) string {
+	var formatted []string
+	for _, kind := range []conf.AssetKind{conf.RootAssetKind, conf.SiteAssetKind, conf.BuildingAssetKind, conf.StoreyAssetKind, conf.SpaceAssetKind, conf.FunctionalGroupAssetKind} {
+		if count, ok := counts[kind]; ok {
+			formatted = append(formatted, fmt.Sprintf("%d %s", count, kindNames[language][kind]))
+		}
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// format returns the failure in the language. The detail is the error as reported, which is not translated.
+func (f failure) format(language string) string {
+	if f.subject == "" {
+		return fmt.Sprintf("%s (%s)", texts[language][string(f.failureType)], f.detail)
+	}
+	return fmt.Sprintf("%s: %s (%s)", texts[language][string(f.failureType)], f.subject, f.detail)
+}
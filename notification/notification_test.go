@@ -0,0 +1,183 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package notification
+
+import (
+	"errors"
+	"signify/apiserver"
+	"signify/conf"
+	"signify/eliona"
+	"testing"
+	"time"
+)
+
+type sentNotification struct {
+	recipient string
+	projectId string
+	de        string
+	en        string
+}
+
+// recordNotifications resets the pending notifications and records the sent ones instead of sending them to Eliona
+func recordNotifications(t *testing.T) *[]sentNotification {
+	var sent []sentNotification
+	summaries = make(map[summaryKey]*summary)
+	lastSent = make(map[int64]time.Time)
+	failing = make(map[int64]map[failureKey]bool)
+	sendNotification = func(recipient string, projectId *string, de string, en string) error {
+		notification := sentNotification{recipient: recipient, de: de, en: en}
+		if projectId != nil {
+			notification.projectId = *projectId
+		}
+		sent = append(sent, notification)
+		return nil
+	}
+	t.Cleanup(func() {
+		sendNotification = eliona.SendNotification
+	})
+	return &sent
+}
+
+func testConfig(id int64, digestInterval int32) apiserver.Configuration {
+	recipients := []string{"user@example.com"}
+	return apiserver.Configuration{Id: &id, NotificationRecipients: &recipients, NotificationDigestInterval: &digestInterval}
+}
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary summary
+		de      string
+		en      string
+	}{
+		{
+			name: "changes",
+			summary: summary{
+				created:   map[conf.AssetKind]int{conf.SpaceAssetKind: 3, conf.BuildingAssetKind: 1},
+				removed:   map[conf.AssetKind]int{conf.StoreyAssetKind: 2},
+				buildings: []string{"Warehouse", "HQ"},
+			},
+			de: "Signify App hat Assets synchronisiert. Angelegt: 1 Gebäude, 3 Räume. Entfernt: 2 Stockwerke. Betroffene Gebäude: HQ, Warehouse.",
+			en: "Signify app synchronized assets. Created: 1 buildings, 3 spaces. Removed: 2 storeys. Affected buildings: HQ, Warehouse.",
+		},
+		{
+			name: "failures",
+			summary: summary{
+				failures: []failure{
+					{failureType: DiscoveryFailure, detail: "timeout"},
+					{failureType: AssetCreationFailure, subject: "1", detail: "forbidden"},
+				},
+			},
+			de: "Signify App meldet Fehler. Fehler: Erkennung der Objekte fehlgeschlagen (timeout); Anlegen der Assets fehlgeschlagen: 1 (forbidden)",
+			en: "Signify app reports failures. Failures: Discovery of the objects failed (timeout); Creating the assets failed: 1 (forbidden)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.summary.message("de"); got != tt.de {
+				t.Errorf("message(de) = %q, want %q", got, tt.de)
+			}
+			if got := tt.summary.message("en"); got != tt.en {
+				t.Errorf("message(en) = %q, want %q", got, tt.en)
+			}
+		})
+	}
+}
+
+func TestSendPerProject(t *testing.T) {
+	sent := recordNotifications(t)
+	config := testConfig(1, 0)
+
+	Created(1, "1", conf.SpaceAssetKind, "HQ")
+	Updated(1, "2", conf.SpaceAssetKind, "HQ")
+	Created(2, "1", conf.SpaceAssetKind, "Warehouse")
+	Send(config)
+
+	if len(*sent) != 2 {
+		t.Fatalf("Send() sent %d notifications, want 2", len(*sent))
+	}
+	for _, notification := range *sent {
+		if notification.projectId != "1" && notification.projectId != "2" {
+			t.Errorf("Send() sent notification for project %q", notification.projectId)
+		}
+	}
+	if _, ok := summaries[summaryKey{configId: 2, projectId: "1"}]; !ok {
+		t.Errorf("Send() removed the summary of another configuration")
+	}
+}
+
+func TestSendDigest(t *testing.T) {
+	sent := recordNotifications(t)
+	config := testConfig(1, 3600)
+
+	Created(1, "1", conf.SpaceAssetKind, "HQ")
+	Send(config)
+	if len(*sent) != 1 {
+		t.Fatalf("first Send() sent %d notifications, want 1", len(*sent))
+	}
+
+	Created(1, "1", conf.SpaceAssetKind, "HQ")
+	Send(config)
+	Created(1, "1", conf.StoreyAssetKind, "HQ")
+	Send(config)
+	if len(*sent) != 1 {
+		t.Fatalf("Send() within the digest interval sent %d notifications, want none", len(*sent)-1)
+	}
+
+	lastSent[1] = time.Now().Add(-time.Hour)
+	Send(config)
+	if len(*sent) != 2 {
+		t.Fatalf("Send() after the digest interval sent %d notifications, want 1", len(*sent)-1)
+	}
+	if want := "Signify app synchronized assets. Created: 1 storeys, 1 spaces. Affected buildings: HQ."; (*sent)[1].en != want {
+		t.Errorf("digest = %q, want %q", (*sent)[1].en, want)
+	}
+}
+
+func TestFailedOncePerEpisode(t *testing.T) {
+	sent := recordNotifications(t)
+	config := testConfig(1, 0)
+	err := errors.New("timeout")
+
+	Failed(1, DiscoveryFailure, "", err)
+	Failed(1, DiscoveryFailure, "", err)
+	Send(config)
+	if len(*sent) != 1 {
+		t.Fatalf("Send() sent %d notifications, want 1", len(*sent))
+	}
+	if want := "Signify app reports failures. Failures: Discovery of the objects failed (timeout)"; (*sent)[0].en != want {
+		t.Errorf("Send() = %q, want %q", (*sent)[0].en, want)
+	}
+
+	Failed(1, DiscoveryFailure, "", err)
+	Send(config)
+	if len(*sent) != 1 {
+		t.Fatalf("Send() notified an ongoing failure again")
+	}
+
+	Failed(1, AssetCreationFailure, "1", err)
+	Send(config)
+	if len(*sent) != 2 {
+		t.Fatalf("Send() didn't notify another failure")
+	}
+
+	Recovered(1, DiscoveryFailure, "")
+	Failed(1, DiscoveryFailure, "", err)
+	Send(config)
+	if len(*sent) != 3 {
+		t.Fatalf("Send() didn't notify a failure after recovery")
+	}
+}
@@ -18,8 +18,11 @@ package signify
 import (
 	"fmt"
 	utilshttp "github.com/eliona-smart-building-assistant/go-utils/http"
-	"github.com/eliona-smart-building-assistant/go-utils/log"
 	"signify/apiserver"
+	"signify/events"
+	"signify/logging"
+	"signify/metrics"
+	"sync"
 	"time"
 )
 
@@ -30,10 +33,16 @@ type BearerToken struct {
 	Issued    int64
 }
 
+// getBearerToken returns the bearer token of the configuration and requests a new one if it is expired. Only one token
+// is requested at a time per configuration, so concurrent callers wait for it instead of requesting their own. The
+// token requests of other configurations are not blocked.
 func getBearerToken(config apiserver.Configuration) (*BearerToken, error) {
-	if bearerTokenValid(config) {
-		log.Debug("auth", "Reuse bearer bearerToken: %.10s...", bearerTokens[*config.Id].Token)
-		return bearerTokens[*config.Id], nil
+	requestMutex := tokenRequestMutex(*config.Id)
+	requestMutex.Lock()
+	defer requestMutex.Unlock()
+	if token := validBearerToken(config); token != nil {
+		logging.Config("auth", *config.Id).Debug(fmt.Sprintf("Reuse bearer token: %.10s...", token.Token))
+		return token, nil
 	}
 	request, err := utilshttp.NewPostFormRequestWithBasicAuth(config.BaseUrl+"/oauth/accesstoken", map[string][]string{
 		"app_key":    {config.AppKey},
@@ -43,27 +52,73 @@ func getBearerToken(config apiserver.Configuration) (*BearerToken, error) {
 	if err != nil {
 		return nil, fmt.Errorf("request /oauth/accesstoken: %w", err)
 	}
-	token, err := utilshttp.Read[BearerToken](request, time.Duration(*config.RequestTimeout)*time.Second, true)
+	token, err := readInteract[BearerToken](config, "accesstoken", request)
+	if err == nil && token.Fault != nil {
+		err = fmt.Errorf("%v", token.Fault["faultstring"])
+	}
+	metrics.ObserveTokenRefresh(*config.Id, err)
+	setTokenError(*config.Id, err)
 	if err != nil {
+		events.Record(*config.Id, events.TokenFailedEventType, "Acquiring bearer token failed: %v", err)
 		return nil, fmt.Errorf("read /oauth/accesstoken: %w", err)
 	}
-	if token.Fault != nil {
-		return nil, fmt.Errorf("read /oauth/accesstoken: %v", token.Fault["faultstring"])
-	}
 	token.Issued = time.Now().Unix()
+	bearerTokensMutex.Lock()
 	bearerTokens[*config.Id] = &token
-	log.Info("auth", "Created new Bearer Token for %d: %.10s...", *config.Id, token.Token)
+	bearerTokensMutex.Unlock()
+	logging.Config("auth", *config.Id).Info(fmt.Sprintf("Created new bearer token: %.10s...", token.Token))
 	return &token, nil
 }
 
 var bearerTokens = make(map[int64]*BearerToken)
+var bearerTokensMutex sync.Mutex
+
+// tokenRequestMutexes serialize the token requests of each configuration
+var tokenRequestMutexes = make(map[int64]*sync.Mutex)
+
+func tokenRequestMutex(configId int64) *sync.Mutex {
+	bearerTokensMutex.Lock()
+	defer bearerTokensMutex.Unlock()
+	mutex, ok := tokenRequestMutexes[configId]
+	if !ok {
+		mutex = &sync.Mutex{}
+		tokenRequestMutexes[configId] = mutex
+	}
+	return mutex
+}
 
 func resetBearerToken(config apiserver.Configuration) {
-	log.Info("auth", "Reset Bearer Token for %d", *config.Id)
+	bearerTokensMutex.Lock()
+	defer bearerTokensMutex.Unlock()
+	logging.Config("auth", *config.Id).Info("Reset bearer token")
 	delete(bearerTokens, *config.Id)
 }
 
-func bearerTokenValid(config apiserver.Configuration) bool {
+// tokenErrors holds the error of the last token request of each configuration, nil if it succeeded
+var tokenErrors = make(map[int64]error)
+var tokenErrorsMutex sync.Mutex
+
+func setTokenError(configId int64, err error) {
+	tokenErrorsMutex.Lock()
+	defer tokenErrorsMutex.Unlock()
+	tokenErrors[configId] = err
+}
+
+// TokenStatus returns the error of the last token request of the configuration. It doesn't request a token itself,
+// so it can be called as often as needed, e.g. by readiness probes.
+func TokenStatus(config apiserver.Configuration) error {
+	tokenErrorsMutex.Lock()
+	defer tokenErrorsMutex.Unlock()
+	return tokenErrors[*config.Id]
+}
+
+// validBearerToken returns the bearer token of the configuration, nil if there is none or it is expired
+func validBearerToken(config apiserver.Configuration) *BearerToken {
+	bearerTokensMutex.Lock()
+	defer bearerTokensMutex.Unlock()
 	token, found := bearerTokens[*config.Id]
-	return found && token.Token != "" && token.Issued+int64(token.ExpiresIn) >= time.Now().Unix()-300
+	if found && token.Token != "" && token.Issued+int64(token.ExpiresIn) >= time.Now().Unix()-300 {
+		return token
+	}
+	return nil
 }
@@ -0,0 +1,113 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package signify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"signify/apiserver"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// tokenServer serves bearer tokens after the release channel is closed and counts the token requests
+func tokenServer(t *testing.T, release chan struct{}) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"token": "new-token", "expires_in": 3600}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func tokenConfig(id int64, baseUrl string) apiserver.Configuration {
+	timeout := int32(5)
+	return apiserver.Configuration{Id: &id, BaseUrl: baseUrl, RequestTimeout: &timeout}
+}
+
+func TestGetBearerTokenDoesNotBlockOtherConfigurations(t *testing.T) {
+	release := make(chan struct{})
+	server, _ := tokenServer(t, release)
+	slow := tokenConfig(-2, server.URL)
+	cached := tokenConfig(-3, server.URL)
+	bearerTokens[*cached.Id] = &BearerToken{Token: "cached-token", ExpiresIn: 3600, Issued: time.Now().Unix()}
+	t.Cleanup(func() {
+		delete(bearerTokens, *slow.Id)
+		delete(bearerTokens, *cached.Id)
+	})
+
+	requested := make(chan struct{})
+	go func() {
+		defer close(requested)
+		_, _ = getBearerToken(slow)
+	}()
+	defer func() {
+		close(release)
+		<-requested
+	}()
+
+	// wait until the slow configuration holds its lock for the token request
+	time.Sleep(50 * time.Millisecond)
+	found := make(chan *BearerToken)
+	go func() {
+		token, _ := getBearerToken(cached)
+		found <- token
+	}()
+	select {
+	case token := <-found:
+		if token == nil || token.Token != "cached-token" {
+			t.Errorf("getBearerToken() = %v, want the cached token", token)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("getBearerToken() is blocked by the token request of another configuration")
+	}
+}
+
+func TestGetBearerTokenRequestsOncePerConfiguration(t *testing.T) {
+	release := make(chan struct{})
+	server, requests := tokenServer(t, release)
+	config := tokenConfig(-4, server.URL)
+	t.Cleanup(func() {
+		delete(bearerTokens, *config.Id)
+	})
+
+	var wg sync.WaitGroup
+	tokens := make([]*BearerToken, 3)
+	for i := range tokens {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tokens[i], _ = getBearerToken(config)
+		}(i)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("getBearerToken() requested %d tokens, want 1", got)
+	}
+	for i, token := range tokens {
+		if token == nil || token.Token != "new-token" {
+			t.Errorf("getBearerToken()[%d] = %v, want the new token", i, token)
+		}
+	}
+}
@@ -16,14 +16,25 @@
 package signify
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"path"
+	"regexp"
 	"signify/apiserver"
+	"signify/conf"
 	"signify/eliona"
+	"signify/events"
+	"signify/logging"
+	"signify/metrics"
+	"signify/notification"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/eliona-smart-building-assistant/go-utils/common"
 	utilshttp "github.com/eliona-smart-building-assistant/go-utils/http"
-	"github.com/eliona-smart-building-assistant/go-utils/log"
 	"github.com/gorilla/websocket"
 )
 
@@ -33,7 +44,54 @@ type Object struct {
 	Uuid         string     `json:"uuid" eliona:"uuid,filterable"`
 	FunctionType string     `json:"functionType" eliona:"function_type,filterable"`
 	SpaceType    string     `json:"spaceType" eliona:"space_type,filterable"`
-	Children     []Object
+	Latitude     *float64   `json:"latitude"`
+	Longitude    *float64   `json:"longitude"`
+
+	// Computed from the hierarchy the object is part of
+	SiteName     string `json:"-" eliona:"site_name,filterable"`
+	BuildingName string `json:"-" eliona:"building_name,filterable"`
+	StoreyName   string `json:"-" eliona:"storey_name,filterable"`
+	StoreyLevel  string `json:"-" eliona:"storey_level,filterable"`
+	Path         string `json:"-" eliona:"path,filterable"`
+
+	// Static details, only fetched for spaces
+	Details *SpaceDetails `json:"-"`
+
+	Children []Object
+}
+
+type SpaceDetails struct {
+	Area         *float64 `json:"area" eliona:"area" subtype:"info"`
+	Capacity     *int     `json:"capacity" eliona:"capacity" subtype:"info"`
+	FunctionType string   `json:"functionType" eliona:"function_type" subtype:"info"`
+}
+
+var storeyLevelPattern = regexp.MustCompile(`-?\d+`)
+
+// setHierarchy sets the attributes computed from the parent objects. The storey level is the first
+// integer in the storey name, e.g. 2 for "Floor 2". Objects without own location inherit the location of the parent.
+func (object *Object) setHierarchy(parent *Object) {
+	object.Path = object.Name
+	if parent != nil {
+		object.SiteName = parent.SiteName
+		object.BuildingName = parent.BuildingName
+		object.StoreyName = parent.StoreyName
+		object.StoreyLevel = parent.StoreyLevel
+		object.Path = parent.Path + "/" + object.Name
+		if object.Latitude == nil && object.Longitude == nil {
+			object.Latitude = parent.Latitude
+			object.Longitude = parent.Longitude
+		}
+	}
+	switch object.ObjectType {
+	case SiteObjectType:
+		object.SiteName = object.Name
+	case BuildingObjectType:
+		object.BuildingName = object.Name
+	case StoreyObjectType:
+		object.StoreyName = object.Name
+		object.StoreyLevel = storeyLevelPattern.FindString(object.Name)
+	}
 }
 
 const (
@@ -87,8 +145,17 @@ type WebsocketUrl struct {
 }
 
 var subscriptions = make(map[int64][]*websocket.Conn)
+var openSubscriptions = make(map[int64]int)
+var subscriptionsMutex sync.Mutex
+
+// subscriptionGenerations counts the closings of the subscriptions of each configuration. A subscription connecting
+// after the subscriptions of its configuration were closed is dropped.
+var subscriptionGenerations = make(map[int64]int)
 
-func fetchObjects(config apiserver.Configuration, endpoint string, objectType ObjectType) ([]Object, error) {
+// errSubscriptionsClosed is returned if the subscriptions were closed while a subscription was connecting
+var errSubscriptionsClosed = errors.New("subscriptions closed while connecting")
+
+func fetchObjects(config apiserver.Configuration, endpoint string, objectType ObjectType, parent *Object) ([]Object, error) {
 	token, err := getBearerToken(config)
 	if err != nil {
 		return nil, err
@@ -100,7 +167,7 @@ func fetchObjects(config apiserver.Configuration, endpoint string, objectType Ob
 		return nil, fmt.Errorf("request %s: %w", endpoint, err)
 	}
 
-	objects, err := utilshttp.Read[[]Object](request, time.Duration(*config.RequestTimeout)*time.Second, true)
+	objects, err := readInteract[[]Object](config, path.Base(endpoint), request)
 	if err != nil {
 		resetBearerToken(config)
 		return nil, fmt.Errorf("read %s: %w", endpoint, err)
@@ -108,8 +175,14 @@ func fetchObjects(config apiserver.Configuration, endpoint string, objectType Ob
 	var filteredObjects = make([]Object, 0)
 	for _, object := range objects {
 		object.ObjectType = objectType
+		object.setHierarchy(parent)
+
+		if slices.Contains(conf.ExcludedUUIDs(config), object.Uuid) {
+			logging.Config("collect", *config.Id).Debug("Skipping excluded object", "object_type", objectType, "name", object.Name)
+			continue
+		}
 
-		shouldUse, err := eliona.AdheresToFilter(object, config.AssetFilter)
+		shouldUse, err := eliona.AdheresToFilter(object, string(objectType), config.AssetFilter)
 		if err != nil {
 			return nil, fmt.Errorf("filtering object %s: %w", object.Name, err)
 		}
@@ -122,41 +195,117 @@ func fetchObjects(config apiserver.Configuration, endpoint string, objectType Ob
 	return filteredObjects, nil
 }
 
+// FilterObjects returns the objects adhering to the filter. An object not adhering excludes its whole subtree.
+func FilterObjects(objects []Object, filter [][]apiserver.FilterRule) ([]Object, error) {
+	var filteredObjects = make([]Object, 0)
+	for _, object := range objects {
+		shouldUse, err := eliona.AdheresToFilter(object, string(object.ObjectType), filter)
+		if err != nil {
+			return nil, fmt.Errorf("filtering object %s: %w", object.Name, err)
+		}
+		if !shouldUse {
+			continue
+		}
+		object.Children, err = FilterObjects(object.Children, filter)
+		if err != nil {
+			return nil, err
+		}
+		filteredObjects = append(filteredObjects, object)
+	}
+	return filteredObjects, nil
+}
+
 func GetSites(config apiserver.Configuration) ([]Object, error) {
-	return fetchObjects(config, "/interact/api/officeCloud/v1/sites", SiteObjectType)
+	return fetchObjects(config, "/interact/api/officeCloud/v1/sites", SiteObjectType, nil)
 }
 
 func GetBuildings(config apiserver.Configuration, site Object) ([]Object, error) {
-	return fetchObjects(config, "/interact/api/officeCloud/v1/sites/"+site.Uuid+"/buildings", BuildingObjectType)
+	return fetchObjects(config, "/interact/api/officeCloud/v1/sites/"+site.Uuid+"/buildings", BuildingObjectType, &site)
 }
 
 func GetStoreys(config apiserver.Configuration, building Object) ([]Object, error) {
-	return fetchObjects(config, "/interact/api/officeCloud/v1/buildings/"+building.Uuid+"/buildingStoreys", StoreyObjectType)
+	return fetchObjects(config, "/interact/api/officeCloud/v1/buildings/"+building.Uuid+"/buildingStoreys", StoreyObjectType, &building)
 }
 
 func GetSensorSpaces(config apiserver.Configuration, storey Object) ([]Object, error) {
-	return fetchObjects(config, "/interact/api/officeCloud/v1/buildingStoreys/"+storey.Uuid+"/sensorSpaces", SpaceObjectType)
+	return fetchObjects(config, "/interact/api/officeCloud/v1/buildingStoreys/"+storey.Uuid+"/sensorSpaces", SpaceObjectType, &storey)
+}
+
+// GetSpaceDetails returns the static details like area and capacity of a space. The details are fetched with each
+// discovery, so changes in Interact are picked up with the next synchronisation.
+func GetSpaceDetails(config apiserver.Configuration, space Object) (*SpaceDetails, error) {
+	token, err := getBearerToken(config)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := "/interact/api/officeCloud/v1/spaces/" + space.Uuid
+	request, err := utilshttp.NewRequestWithBearer(config.BaseUrl+endpoint, token.Token)
+	if err != nil {
+		return nil, fmt.Errorf("request %s: %w", endpoint, err)
+	}
+
+	// a single space failing doesn't invalidate the token, only a rejected token does
+	details, status, err := readInteractWithStatus[SpaceDetails](config, "spaces", request)
+	if err != nil {
+		if status == http.StatusUnauthorized {
+			resetBearerToken(config)
+		}
+		return nil, fmt.Errorf("read %s: %w", endpoint, err)
+	}
+	if details.FunctionType == "" {
+		details.FunctionType = space.FunctionType
+	}
+	return &details, nil
 }
 
-func Subscribe(config apiserver.Configuration, url string, messageHandler func(message Message)) {
+func Subscribe(config apiserver.Configuration, buildingUUID string, subscriptionType SubscriptionType, url string, messageHandler func(message Message)) {
 	messages := make(chan Message)
+	logger := logging.Config("listening", *config.Id).With(logging.BuildingUUID(buildingUUID), logging.SubscriptionType(string(subscriptionType)), "url", url)
+
+	subscriptionsMutex.Lock()
+	generation := subscriptionGenerations[*config.Id]
+	subscriptionsMutex.Unlock()
+	subject := notification.SubscriptionSubject(string(subscriptionType), buildingUUID)
 
 	// start listening
 	go func() {
-		subscription, err := createSubscription(config, url)
+		subscription, err := createSubscription(config, url, generation, logger)
+		if errors.Is(err, errSubscriptionsClosed) {
+			logger.Debug("Dropped subscription closed while connecting")
+			close(messages)
+			return
+		}
 		if err != nil {
-			log.Error("Listening", "Error creating subscription on %s: %v", url, err)
+			notification.Failed(*config.Id, notification.SubscriptionFailure, subject, err)
+			events.RecordStopped(*config.Id, subject, events.SubscriptionStoppedEventType, "Subscription %s stopped: %v", subject, err)
+			logger.Error("Error creating subscription", "error", err)
+			close(messages)
+			return
 		}
+		notification.Recovered(*config.Id, notification.SubscriptionFailure, subject)
+		setSubscriptionOpen(config, subscriptionType, true)
+		events.RecordStarted(*config.Id, subject, events.SubscriptionStartedEventType, "Subscription %s started on %s", subject, url)
 		err = utilshttp.ListenWebSocket(subscription, messages)
+		setSubscriptionOpen(config, subscriptionType, false)
+		// a subscription closed to resubscribe keeps running, its stop is recorded if it isn't resubscribed
+		if !resubscribed(config, generation) {
+			if err != nil {
+				events.RecordStopped(*config.Id, subject, events.SubscriptionStoppedEventType, "Subscription %s on %s stopped: %v", subject, url, err)
+			} else {
+				events.RecordStopped(*config.Id, subject, events.SubscriptionStoppedEventType, "Subscription %s stopped on %s", subject, url)
+			}
+		}
 		close(messages)
 		if err != nil {
-			log.Error("Listening", "Error listening on %s: %v", url, err)
+			logger.Error("Error listening", "error", err)
 		}
 	}()
 	go func() {
-		log.Debug("Listening", "Start listening on: %s", url)
+		logger.Debug("Start listening")
 		for message := range messages {
-			log.Debug("Listening", "New message from %s: %v", url, message)
+			logger.Debug("New message", "space_uuid", message.SpaceId, "message", message)
+			metrics.MessageReceived(*config.Id, string(subscriptionType))
 			if message.OccupancyState != nil {
 				switch *message.OccupancyState {
 				case OccupiedOccupancyState:
@@ -169,16 +318,22 @@ func Subscribe(config apiserver.Configuration, url string, messageHandler func(m
 			}
 			messageHandler(message)
 		}
-		log.Info("Listening", "n %s", url)
+		logger.Info("Stopped listening")
 	}()
 }
 
-func createSubscription(config apiserver.Configuration, url string) (*websocket.Conn, error) {
-	log.Info("Listening", "Create subscription for %s", url)
+func createSubscription(config apiserver.Configuration, url string, generation int, logger *slog.Logger) (*websocket.Conn, error) {
+	logger.Info("Create subscription")
 	subscription, err := utilshttp.NewWebSocketConnectionWithApiKey(url, "", "")
 	if err != nil {
 		return nil, err
 	}
+	subscriptionsMutex.Lock()
+	defer subscriptionsMutex.Unlock()
+	if subscriptionGenerations[*config.Id] != generation {
+		_ = subscription.Close()
+		return nil, errSubscriptionsClosed
+	}
 	var _, found = subscriptions[*config.Id]
 	if !found {
 		subscriptions[*config.Id] = []*websocket.Conn{}
@@ -187,14 +342,43 @@ func createSubscription(config apiserver.Configuration, url string) (*websocket.
 	return subscription, nil
 }
 
+// resubscribed returns true if the subscriptions of the generation were closed to subscribe again
+func resubscribed(config apiserver.Configuration, generation int) bool {
+	subscriptionsMutex.Lock()
+	defer subscriptionsMutex.Unlock()
+	return subscriptionGenerations[*config.Id] != generation
+}
+
+func setSubscriptionOpen(config apiserver.Configuration, subscriptionType SubscriptionType, open bool) {
+	subscriptionsMutex.Lock()
+	defer subscriptionsMutex.Unlock()
+	if open {
+		openSubscriptions[*config.Id]++
+		metrics.WebsocketConnected(*config.Id, string(subscriptionType))
+	} else {
+		openSubscriptions[*config.Id]--
+		metrics.WebsocketDisconnected(*config.Id, string(subscriptionType))
+	}
+}
+
+// OpenSubscriptions returns the number of currently open websocket subscriptions for a configuration
+func OpenSubscriptions(configId int64) int {
+	subscriptionsMutex.Lock()
+	defer subscriptionsMutex.Unlock()
+	return openSubscriptions[configId]
+}
+
 func CloseExistingSubscriptions(config apiserver.Configuration) {
+	subscriptionsMutex.Lock()
+	defer subscriptionsMutex.Unlock()
 	for _, subscription := range subscriptions[*config.Id] {
 		if subscription != nil {
-			log.Debug("Listening", "Stopping listening for subscription %v", subscription)
+			logging.Config("listening", *config.Id).Debug("Stopping listening for subscription", "remote_address", subscription.RemoteAddr())
 			_ = subscription.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
 		}
 	}
 	subscriptions[*config.Id] = []*websocket.Conn{}
+	subscriptionGenerations[*config.Id]++
 }
 
 func GetSubscriptionUrl(config apiserver.Configuration, buildingUUID string, subscriptionType SubscriptionType) (*string, error) {
@@ -208,7 +392,7 @@ func GetSubscriptionUrl(config apiserver.Configuration, buildingUUID string, sub
 		return nil, fmt.Errorf("request %s: %w", endpoint, err)
 	}
 
-	websocketUrl, err := utilshttp.Read[WebsocketUrl](request, time.Duration(*config.RequestTimeout)*time.Second, true)
+	websocketUrl, err := readInteract[WebsocketUrl](config, "subscription", request)
 	if err != nil {
 		return nil, fmt.Errorf("read %s: %w", endpoint, err)
 	}
@@ -217,3 +401,18 @@ func GetSubscriptionUrl(config apiserver.Configuration, buildingUUID string, sub
 	}
 	return websocketUrl.Url, nil
 }
+
+// readInteract reads the response of a request to the Interact API and records the request metrics.
+// The endpoint is only used as metrics label and must not contain object UUIDs.
+func readInteract[T any](config apiserver.Configuration, endpoint string, request *http.Request) (T, error) {
+	result, _, err := readInteractWithStatus[T](config, endpoint, request)
+	return result, err
+}
+
+// readInteractWithStatus reads like readInteract and returns the response status in addition
+func readInteractWithStatus[T any](config apiserver.Configuration, endpoint string, request *http.Request) (T, int, error) {
+	start := time.Now()
+	result, status, err := utilshttp.ReadWithStatusCode[T](request, time.Duration(*config.RequestTimeout)*time.Second, true)
+	metrics.ObserveInteractRequest(endpoint, status, time.Since(start))
+	return result, status, err
+}
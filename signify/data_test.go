@@ -0,0 +1,207 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package signify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"signify/apiserver"
+	"signify/eliona"
+	"testing"
+	"time"
+)
+
+func TestSetHierarchy(t *testing.T) {
+	site := Object{ObjectType: SiteObjectType, Name: "Zurich"}
+	site.setHierarchy(nil)
+	building := Object{ObjectType: BuildingObjectType, Name: "HQ"}
+	building.setHierarchy(&site)
+	storey := Object{ObjectType: StoreyObjectType, Name: "Floor -2 East"}
+	storey.setHierarchy(&building)
+	space := Object{ObjectType: SpaceObjectType, Name: "Meeting Room 12"}
+	space.setHierarchy(&storey)
+
+	tests := []struct {
+		name   string
+		object Object
+		want   Object
+	}{
+		{"site", site, Object{SiteName: "Zurich", Path: "Zurich"}},
+		{"building", building, Object{SiteName: "Zurich", BuildingName: "HQ", Path: "Zurich/HQ"}},
+		{"storey", storey, Object{SiteName: "Zurich", BuildingName: "HQ", StoreyName: "Floor -2 East", StoreyLevel: "-2",
+			Path: "Zurich/HQ/Floor -2 East"}},
+		{"space", space, Object{SiteName: "Zurich", BuildingName: "HQ", StoreyName: "Floor -2 East", StoreyLevel: "-2",
+			Path: "Zurich/HQ/Floor -2 East/Meeting Room 12"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.object
+			if got.SiteName != tt.want.SiteName || got.BuildingName != tt.want.BuildingName || got.StoreyName != tt.want.StoreyName ||
+				got.StoreyLevel != tt.want.StoreyLevel || got.Path != tt.want.Path {
+				t.Errorf("setHierarchy() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterByHierarchy(t *testing.T) {
+	storey := Object{ObjectType: StoreyObjectType, Name: "Floor 3"}
+	storey.setHierarchy(&Object{ObjectType: BuildingObjectType, Name: "HQ", BuildingName: "HQ", Path: "HQ"})
+	space := Object{ObjectType: SpaceObjectType, Name: "Kitchen"}
+	space.setHierarchy(&storey)
+
+	eq, gte := "eq", "gte"
+	tests := []struct {
+		name string
+		rule apiserver.FilterRule
+		want bool
+	}{
+		{"building name", apiserver.FilterRule{Parameter: "building_name", Operator: &eq, Value: "HQ"}, true},
+		{"other building name", apiserver.FilterRule{Parameter: "building_name", Operator: &eq, Value: "Warehouse"}, false},
+		{"storey level", apiserver.FilterRule{Parameter: "storey_level", Operator: &gte, Value: "2"}, true},
+		{"storey level above", apiserver.FilterRule{Parameter: "storey_level", Operator: &gte, Value: "4"}, false},
+		{"path", apiserver.FilterRule{Parameter: "path", Regex: "^HQ/Floor 3/"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := eliona.AdheresToFilter(space, string(SpaceObjectType), [][]apiserver.FilterRule{{tt.rule}})
+			if err != nil {
+				t.Fatalf("AdheresToFilter() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("AdheresToFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchObjectsExcludedUUIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"name": "HQ", "uuid": "b1"},
+			{"name": "Warehouse", "uuid": "b2"},
+			{"name": "Lab", "uuid": "b3"}
+		]`))
+	}))
+	defer server.Close()
+
+	id := int64(-1)
+	timeout := int32(5)
+	operator := "regex"
+	buildingObjectType := string(BuildingObjectType)
+	bearerTokens[id] = &BearerToken{Token: "token", ExpiresIn: 3600, Issued: time.Now().Unix()}
+	defer delete(bearerTokens, id)
+
+	tests := []struct {
+		name     string
+		excluded *[]string
+		filter   [][]apiserver.FilterRule
+		want     []string
+	}{
+		{"nothing excluded", nil, nil, []string{"HQ", "Warehouse", "Lab"}},
+		{"excluded uuids are pruned", &[]string{"b2"}, nil, []string{"HQ", "Lab"}},
+		{"excluded uuids are pruned before filtering", &[]string{"b1"},
+			[][]apiserver.FilterRule{{{ObjectType: &buildingObjectType, Parameter: "name", Operator: &operator, Value: "a"}}},
+			[]string{"Warehouse", "Lab"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := apiserver.Configuration{
+				Id:             &id,
+				BaseUrl:        server.URL,
+				RequestTimeout: &timeout,
+				ExcludedUUIDs:  tt.excluded,
+				AssetFilter:    tt.filter,
+			}
+			objects, err := fetchObjects(config, "/buildings", BuildingObjectType, nil)
+			if err != nil {
+				t.Fatalf("fetchObjects() error = %v", err)
+			}
+			if len(objects) != len(tt.want) {
+				t.Fatalf("fetchObjects() returned %d objects, want %v", len(objects), tt.want)
+			}
+			for i, object := range objects {
+				if object.Name != tt.want[i] {
+					t.Errorf("fetchObjects()[%d] = %s, want %s", i, object.Name, tt.want[i])
+				}
+				if object.ObjectType != BuildingObjectType {
+					t.Errorf("fetchObjects()[%d] object type = %s, want %s", i, object.ObjectType, BuildingObjectType)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterObjects(t *testing.T) {
+	objects := []Object{
+		{ObjectType: BuildingObjectType, Name: "HQ", Children: []Object{
+			{ObjectType: StoreyObjectType, Name: "Floor 1", Children: []Object{
+				{ObjectType: SpaceObjectType, Name: "Meeting Room", SpaceType: "occupancy"},
+				{ObjectType: SpaceObjectType, Name: "Kitchen", SpaceType: "temperature"},
+			}},
+			{ObjectType: StoreyObjectType, Name: "Basement"},
+		}},
+		{ObjectType: BuildingObjectType, Name: "Warehouse"},
+	}
+	eq, regex := "eq", "regex"
+	building, storey, space := string(BuildingObjectType), string(StoreyObjectType), string(SpaceObjectType)
+	negate := true
+	tests := []struct {
+		name   string
+		filter [][]apiserver.FilterRule
+		want   []string
+	}{
+		{"no filter", nil, []string{"HQ", "HQ/Floor 1", "HQ/Floor 1/Meeting Room", "HQ/Floor 1/Kitchen", "HQ/Basement", "Warehouse"}},
+		{"prunes buildings with their children",
+			[][]apiserver.FilterRule{{{ObjectType: &building, Parameter: "name", Operator: &eq, Value: "Warehouse"}}},
+			[]string{"Warehouse"}},
+		{"keeps parents of matching spaces",
+			[][]apiserver.FilterRule{{{ObjectType: &space, Parameter: "space_type", Operator: &eq, Value: "occupancy"}}},
+			[]string{"HQ", "HQ/Floor 1", "HQ/Floor 1/Meeting Room", "HQ/Basement", "Warehouse"}},
+		{"prunes storeys with their children",
+			[][]apiserver.FilterRule{{{ObjectType: &storey, Parameter: "name", Operator: &regex, Value: "^Floor", Negate: &negate}}},
+			[]string{"HQ", "HQ/Basement", "Warehouse"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered, err := FilterObjects(objects, tt.filter)
+			if err != nil {
+				t.Fatalf("FilterObjects() error = %v", err)
+			}
+			got := objectPaths(filtered, "")
+			if len(got) != len(tt.want) {
+				t.Fatalf("FilterObjects() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("FilterObjects() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func objectPaths(objects []Object, prefix string) []string {
+	var paths []string
+	for _, object := range objects {
+		path := prefix + object.Name
+		paths = append(paths, path)
+		paths = append(paths, objectPaths(object.Children, path+"/")...)
+	}
+	return paths
+}